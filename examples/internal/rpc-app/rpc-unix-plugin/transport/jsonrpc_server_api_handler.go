@@ -41,6 +41,12 @@ func (v *JSONRPCApiTransport) JSONRPCApiHandlers() map[string]jsonrpc.THandleFun
 	}
 }
 
+func (v *JSONRPCApiTransport) JSONRPCApiModels() map[string]jsonrpc.TModel {
+	return map[string]jsonrpc.TModel{
+		"api.ping": {Request: &jsonrpcApiPingModelRequest{}, Response: &jsonrpcApiPingModelResponse{}},
+	}
+}
+
 func (v *JSONRPCApiTransport) CallPing(ctx context.Context, webCtx web.Ctx, param stdjson.RawMessage) (any, error) {
 	var req jsonrpcApiPingModelRequest
 	err := stdjson.Unmarshal(param, &req)
@@ -18,6 +18,7 @@ import (
 	"go.osspkg.com/goppy/v3"
 	"go.osspkg.com/goppy/v3/plugins/web"
 	"go.osspkg.com/goppy/v3/plugins/web/jsonrpc"
+	"go.osspkg.com/goppy/v3/plugins/web/openapi"
 )
 
 func main() {
@@ -34,6 +35,11 @@ func main() {
 				return err
 			}),
 		),
+		openapi.WithOpenAPI(
+			openapi.Title("transport-generate"),
+			openapi.APIVersion("v1.0.0"),
+		),
+		openapi.WithJSONRPC(),
 	)
 	app.Plugins(
 		NewController,
@@ -50,6 +50,13 @@ func (v *JSONRPCApiTransport) JSONRPCApiHandlers() map[string]jsonrpc.THandleFun
 	}
 }
 
+func (v *JSONRPCApiTransport) JSONRPCApiModels() map[string]jsonrpc.TModel {
+	return map[string]jsonrpc.TModel{
+		"api.rootv1": {Request: &jsonrpcApiRootV1ModelRequest{}, Response: &jsonrpcApiRootV1ModelResponse{}},
+		"api.authv1": {Request: &jsonrpcApiAuthV1ModelRequest{}, Response: &jsonrpcApiAuthV1ModelResponse{}},
+	}
+}
+
 func (v *JSONRPCApiTransport) CallRootV1(ctx context.Context, webCtx web.Ctx, param stdjson.RawMessage) (any, error) {
 	var req jsonrpcApiRootV1ModelRequest
 	err := stdjson.Unmarshal(param, &req)
@@ -42,6 +42,13 @@ func (v *JSONRPCPostTransport) JSONRPCApiHandlers() map[string]jsonrpc.THandleFu
 	}
 }
 
+func (v *JSONRPCPostTransport) JSONRPCApiModels() map[string]jsonrpc.TModel {
+	return map[string]jsonrpc.TModel{
+		"post.byid": {Request: &jsonrpcPostByIDModelRequest{}, Response: &jsonrpcPostByIDModelResponse{}},
+		"post.list": {Request: &jsonrpcPostListModelRequest{}, Response: &jsonrpcPostListModelResponse{}},
+	}
+}
+
 func (v *JSONRPCPostTransport) CallByID(ctx context.Context, webCtx web.Ctx, param stdjson.RawMessage) (any, error) {
 	var req jsonrpcPostByIDModelRequest
 	err := stdjson.Unmarshal(param, &req)
@@ -41,6 +41,12 @@ func (v *JSONRPCUserTransport) JSONRPCApiHandlers() map[string]jsonrpc.THandleFu
 	}
 }
 
+func (v *JSONRPCUserTransport) JSONRPCApiModels() map[string]jsonrpc.TModel {
+	return map[string]jsonrpc.TModel{
+		"user.namev1": {Request: &jsonrpcUserNameV1ModelRequest{}, Response: &jsonrpcUserNameV1ModelResponse{}},
+	}
+}
+
 func (v *JSONRPCUserTransport) CallNameV1(ctx context.Context, webCtx web.Ctx, param stdjson.RawMessage) (any, error) {
 	var req jsonrpcUserNameV1ModelRequest
 	err := stdjson.Unmarshal(param, &req)
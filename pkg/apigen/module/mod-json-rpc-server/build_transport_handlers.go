@@ -99,6 +99,25 @@ func (v Module) buildTransport(
 				),
 			),
 		Line(),
+		Func().Bracket(ID("v").Op("*").ID(trName)).
+			ID("JSONRPCApiModels").Bracket().Map(String(), Pkg("jsonrpc").ID("TModel")).
+			Block(
+				Return().Map(String(), Pkg("jsonrpc").ID("TModel")).Block(
+					func() []types.Token {
+						var models []types.Token
+						for _, method := range object.Methods {
+							models = append(models,
+								Text(strings.ToLower(object.Name+"."+method.Name)).Op(":").Op("{").
+									ID("Request").Op(":").Op("&").ID(fmt.Sprintf(modelNameReq, object.Name+method.Name)).Op("{}").Op(",").
+									ID("Response").Op(":").Op("&").ID(fmt.Sprintf(modelNameRes, object.Name+method.Name)).Op("{}").
+									Op("}").Op(","),
+							)
+						}
+						return models
+					}()...,
+				),
+			),
+		Line(),
 	)
 
 	return
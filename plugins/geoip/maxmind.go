@@ -6,14 +6,20 @@
 package geoip
 
 import (
+	"context"
 	"fmt"
 	"net"
+	"sync/atomic"
 
 	"github.com/oschwald/geoip2-golang"
+	"go.osspkg.com/errors"
 
 	"go.osspkg.com/goppy/v3/plugin"
+	"go.osspkg.com/goppy/v3/plugins/health"
 )
 
+var errNotLoaded = errors.New("maxmind: database not loaded")
+
 // WithMaxMindGeoIP information resolver through local MaxMind database
 func WithMaxMindGeoIP() plugin.Kind {
 	return plugin.Kind{
@@ -31,11 +37,14 @@ type (
 	}
 
 	maxmind struct {
-		conf *ConfigGroup
-		db   *geoip2.Reader
+		conf   *ConfigGroup
+		db     *geoip2.Reader
+		loaded atomic.Bool
 	}
 )
 
+var _ health.Checker = (*maxmind)(nil)
+
 func NewMaxMindGeoIP(c *ConfigGroup) GeoIP {
 	return &maxmind{
 		conf: c,
@@ -48,10 +57,12 @@ func (v *maxmind) Up() error {
 		return fmt.Errorf("maxmind: %w", err)
 	}
 	v.db = db
+	v.loaded.Store(true)
 	return nil
 }
 
 func (v *maxmind) Down() error {
+	v.loaded.Store(false)
 	if v.db != nil {
 		return v.db.Close()
 	}
@@ -65,3 +76,19 @@ func (v *maxmind) Country(ip net.IP) (string, error) {
 	}
 	return value.Country.IsoCode, nil
 }
+
+// HealthChecks the application is not ready until the database is loaded
+func (v *maxmind) HealthChecks() []health.Check {
+	return []health.Check{
+		{
+			Name: "geoip:maxmind",
+			Kind: health.Readiness,
+			Func: func(_ context.Context) error {
+				if !v.loaded.Load() {
+					return errNotLoaded
+				}
+				return nil
+			},
+		},
+	}
+}
@@ -51,6 +51,31 @@ func ResolveIPMiddleware(resolver GeoIP, headers ...string) web.Middleware {
 	}
 }
 
+// ClientIPMiddleware resolves the client IP by web.Ctx ClientIP with the trusted proxies of the server config
+func ClientIPMiddleware(resolver GeoIP) web.Middleware {
+	return func(call func(web.Ctx)) func(web.Ctx) {
+		return func(ctx web.Ctx) {
+			cip := ctx.ClientIP()
+			if cip != nil {
+				ctx.SetContextValue(geoClientIP, cip)
+			}
+
+			if resolver != nil && cip != nil {
+				if country, err := resolver.Country(cip); err != nil {
+					logx.Warn("GeoIP country lookup failed",
+						"err", err,
+						"ip", cip.String(),
+					)
+				} else {
+					ctx.SetContextValue(geoCountryName, country)
+				}
+			}
+
+			call(ctx)
+		}
+	}
+}
+
 func HeadersMiddleware(ipHeader, countryHeader string) web.Middleware {
 	return func(call func(web.Ctx)) func(web.Ctx) {
 		return func(ctx web.Ctx) {
@@ -0,0 +1,32 @@
+/*
+ *  Copyright (c) 2022-2026 Mikhail Knyazhev <markus621@yandex.com>. All rights reserved.
+ *  Use of this source code is governed by a BSD 3-Clause license that can be found in the LICENSE file.
+ */
+
+package health
+
+import (
+	"fmt"
+	"time"
+)
+
+type (
+	ConfigGroup struct {
+		Health Config `yaml:"health"`
+	}
+	Config struct {
+		// Timeout default timeout of the check if the check has no own
+		Timeout time.Duration `yaml:"timeout"`
+	}
+)
+
+func (v *ConfigGroup) Default() {
+	v.Health.Timeout = 5 * time.Second
+}
+
+func (v *ConfigGroup) Validate() error {
+	if v.Health.Timeout <= 0 {
+		return fmt.Errorf("health: timeout must be positive")
+	}
+	return nil
+}
@@ -0,0 +1,242 @@
+/*
+ *  Copyright (c) 2022-2026 Mikhail Knyazhev <markus621@yandex.com>. All rights reserved.
+ *  Use of this source code is governed by a BSD 3-Clause license that can be found in the LICENSE file.
+ */
+
+package health
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"go.osspkg.com/errors"
+)
+
+var (
+	ErrCheckExists  = errors.New("health check already registered")
+	ErrCheckInvalid = errors.New("invalid health check")
+	ErrCheckTimeout = errors.New("health check timeout")
+)
+
+// Kind of the check, the kinds can be combined: Readiness | Liveness
+type Kind uint8
+
+const (
+	// Readiness the application can serve requests, e.g. the database is available
+	Readiness Kind = 1 << iota
+	// Liveness the application works and must not be restarted
+	Liveness
+
+	// All every registered check
+	All = Readiness | Liveness
+)
+
+type (
+	// Check single named check, the default timeout of the registry is used if Timeout is zero
+	Check struct {
+		Name    string
+		Kind    Kind
+		Timeout time.Duration
+		Func    func(ctx context.Context) error
+	}
+
+	// Checker optional interface of the plugin objects, the checks are registered automatically
+	Checker interface {
+		HealthChecks() []Check
+	}
+
+	Registry interface {
+		Register(checks ...Check) error
+		Run(ctx context.Context, kind Kind) Report
+	}
+)
+
+type Status string
+
+const (
+	StatusOK   Status = "ok"
+	StatusFail Status = "fail"
+)
+
+type (
+	Report struct {
+		Status Status   `json:"status"`
+		Checks []Result `json:"checks"`
+	}
+	Result struct {
+		Name     string `json:"name"`
+		Status   Status `json:"status"`
+		Error    string `json:"error,omitempty"`
+		Duration string `json:"duration"`
+	}
+)
+
+/**********************************************************************************************************************/
+
+const defaultTimeout = 5 * time.Second
+
+type registry struct {
+	timeout time.Duration
+	checks  map[string]*check
+	mux     sync.RWMutex
+}
+
+// check the registered check with the state of its last run
+type check struct {
+	Check
+	running bool
+	last    *Result
+	mux     sync.Mutex
+}
+
+// NewRegistry creates the registry, the timeout is used for the checks without own timeout
+func NewRegistry(timeout time.Duration) Registry {
+	return newRegistry(timeout)
+}
+
+func newRegistry(timeout time.Duration) *registry {
+	if timeout <= 0 {
+		timeout = defaultTimeout
+	}
+	return &registry{
+		timeout: timeout,
+		checks:  make(map[string]*check, 10),
+	}
+}
+
+func (v *registry) SetTimeout(timeout time.Duration) {
+	if timeout <= 0 {
+		return
+	}
+
+	v.mux.Lock()
+	defer v.mux.Unlock()
+
+	v.timeout = timeout
+}
+
+// Register adds the checks, the name must be unique
+func (v *registry) Register(checks ...Check) error {
+	v.mux.Lock()
+	defer v.mux.Unlock()
+
+	for _, c := range checks {
+		switch {
+		case len(strings.TrimSpace(c.Name)) == 0:
+			return errors.Wrapf(ErrCheckInvalid, "empty name")
+		case c.Func == nil:
+			return errors.Wrapf(ErrCheckInvalid, "`%s`: empty func", c.Name)
+		case c.Kind&All == 0:
+			return errors.Wrapf(ErrCheckInvalid, "`%s`: unknown kind", c.Name)
+		}
+		if _, ok := v.checks[c.Name]; ok {
+			return errors.Wrapf(ErrCheckExists, "`%s`", c.Name)
+		}
+		v.checks[c.Name] = &check{Check: c}
+	}
+
+	return nil
+}
+
+// Run executes the checks of the kind in parallel, each one is limited by its timeout
+func (v *registry) Run(ctx context.Context, kind Kind) Report {
+	v.mux.RLock()
+	timeout := v.timeout
+	list := make([]*check, 0, len(v.checks))
+	for _, c := range v.checks {
+		if c.Kind&kind != 0 {
+			list = append(list, c)
+		}
+	}
+	v.mux.RUnlock()
+
+	report := Report{
+		Status: StatusOK,
+		Checks: make([]Result, len(list)),
+	}
+
+	wg := sync.WaitGroup{}
+	for i, c := range list {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			report.Checks[i] = c.run(ctx, timeout)
+		}()
+	}
+	wg.Wait()
+
+	slices.SortFunc(report.Checks, func(a, b Result) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+	for _, r := range report.Checks {
+		if r.Status != StatusOK {
+			report.Status = StatusFail
+		}
+	}
+
+	return report
+}
+
+// run waits for the check no longer than the timeout, even if the check ignores the context.
+// The new run is not started while the previous one is in flight, the last result is reported instead
+func (c *check) run(ctx context.Context, timeout time.Duration) Result {
+	if c.Timeout > 0 {
+		timeout = c.Timeout
+	}
+
+	c.mux.Lock()
+	if c.running {
+		result := Result{Name: c.Name, Status: StatusFail, Error: ErrCheckTimeout.Error(), Duration: "0s"}
+		if c.last != nil {
+			result = *c.last
+		}
+		c.mux.Unlock()
+		return result
+	}
+	c.running = true
+	c.mux.Unlock()
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	start := time.Now()
+	errC := make(chan error, 1)
+	go func() {
+		defer func() {
+			if e := recover(); e != nil {
+				errC <- fmt.Errorf("panic: %v", e)
+			}
+			c.mux.Lock()
+			c.running = false
+			c.mux.Unlock()
+		}()
+		errC <- c.Func(ctx)
+	}()
+
+	var err error
+	select {
+	case err = <-errC:
+	case <-ctx.Done():
+		err = ErrCheckTimeout
+	}
+
+	result := Result{
+		Name:     c.Name,
+		Status:   StatusOK,
+		Duration: time.Since(start).String(),
+	}
+	if err != nil {
+		result.Status = StatusFail
+		result.Error = err.Error()
+	}
+
+	c.mux.Lock()
+	c.last = &result
+	c.mux.Unlock()
+
+	return result
+}
@@ -0,0 +1,153 @@
+/*
+ *  Copyright (c) 2022-2026 Mikhail Knyazhev <markus621@yandex.com>. All rights reserved.
+ *  Use of this source code is governed by a BSD 3-Clause license that can be found in the LICENSE file.
+ */
+
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"go.osspkg.com/casecheck"
+	"go.osspkg.com/errors"
+)
+
+func TestUnit_Registry(t *testing.T) {
+	reg := NewRegistry(50 * time.Millisecond)
+
+	ok := func(_ context.Context) error { return nil }
+
+	casecheck.NoError(t, reg.Register(
+		Check{Name: "db", Kind: Readiness, Func: ok},
+		Check{Name: "loop", Kind: Liveness, Func: ok},
+		Check{Name: "both", Kind: All, Func: ok},
+	))
+	casecheck.Error(t, reg.Register(Check{Name: "db", Kind: Readiness, Func: ok}))
+	casecheck.Error(t, reg.Register(Check{Name: "", Kind: Readiness, Func: ok}))
+	casecheck.Error(t, reg.Register(Check{Name: "nil", Kind: Readiness}))
+	casecheck.Error(t, reg.Register(Check{Name: "kind", Func: ok}))
+
+	report := reg.Run(context.Background(), Readiness)
+	casecheck.Equal(t, StatusOK, report.Status)
+	casecheck.Equal(t, 2, len(report.Checks))
+	casecheck.Equal(t, "both", report.Checks[0].Name)
+	casecheck.Equal(t, "db", report.Checks[1].Name)
+
+	casecheck.Equal(t, 2, len(reg.Run(context.Background(), Liveness).Checks))
+	casecheck.Equal(t, 3, len(reg.Run(context.Background(), All).Checks))
+
+	casecheck.NoError(t, reg.Register(
+		Check{Name: "fail", Kind: Readiness, Func: func(_ context.Context) error {
+			return errors.New("broken")
+		}},
+		Check{Name: "slow", Kind: Readiness, Func: func(_ context.Context) error {
+			time.Sleep(time.Second)
+			return nil
+		}},
+		Check{Name: "panic", Kind: Readiness, Func: func(_ context.Context) error {
+			panic("boom")
+		}},
+	))
+
+	start := time.Now()
+	report = reg.Run(context.Background(), Readiness)
+	casecheck.True(t, time.Since(start) < 500*time.Millisecond)
+	casecheck.Equal(t, StatusFail, report.Status)
+
+	errs := make(map[string]string, len(report.Checks))
+	for _, r := range report.Checks {
+		errs[r.Name] = r.Error
+	}
+	casecheck.Equal(t, "", errs["db"])
+	casecheck.Equal(t, "broken", errs["fail"])
+	casecheck.Equal(t, ErrCheckTimeout.Error(), errs["slow"])
+	casecheck.Equal(t, "panic: boom", errs["panic"])
+
+	casecheck.Equal(t, StatusOK, reg.Run(context.Background(), Liveness).Status)
+}
+
+func TestUnit_RegistryInFlight(t *testing.T) {
+	reg := NewRegistry(20 * time.Millisecond)
+
+	var starts atomic.Int64
+	releaseC := make(chan struct{})
+	doneC := make(chan struct{}, 1)
+	casecheck.NoError(t, reg.Register(Check{Name: "hang", Kind: Readiness, Func: func(_ context.Context) error {
+		defer func() { doneC <- struct{}{} }()
+		starts.Add(1)
+		<-releaseC
+		return nil
+	}}))
+
+	for i := 0; i < 3; i++ {
+		report := reg.Run(context.Background(), Readiness)
+		casecheck.Equal(t, StatusFail, report.Status)
+		casecheck.Equal(t, ErrCheckTimeout.Error(), report.Checks[0].Error)
+	}
+	casecheck.Equal(t, int64(1), starts.Load())
+
+	close(releaseC)
+	<-doneC
+
+	report := reg.Run(context.Background(), Readiness)
+	for i := 0; i < 100 && report.Status != StatusOK; i++ {
+		time.Sleep(5 * time.Millisecond)
+		report = reg.Run(context.Background(), Readiness)
+	}
+	casecheck.Equal(t, StatusOK, report.Status)
+	casecheck.Equal(t, int64(2), starts.Load())
+}
+
+func TestUnit_Handler(t *testing.T) {
+	reg := NewRegistry(time.Second)
+	casecheck.NoError(t, reg.Register(
+		Check{Name: "db", Kind: Readiness, Func: func(_ context.Context) error {
+			return errors.New("connection refused")
+		}},
+		Check{Name: "loop", Kind: Liveness, Func: func(_ context.Context) error {
+			return nil
+		}},
+	))
+
+	w := httptest.NewRecorder()
+	Handler(reg, Liveness).ServeHTTP(w, httptest.NewRequest(http.MethodGet, PathLiveness, nil))
+	casecheck.Equal(t, http.StatusOK, w.Code)
+	casecheck.Equal(t, "application/json; charset=utf-8", w.Header().Get("Content-Type"))
+
+	w = httptest.NewRecorder()
+	Handler(reg, Readiness).ServeHTTP(w, httptest.NewRequest(http.MethodGet, PathReadiness, nil))
+	casecheck.Equal(t, http.StatusServiceUnavailable, w.Code)
+
+	var report Report
+	casecheck.NoError(t, json.Unmarshal(w.Body.Bytes(), &report))
+	casecheck.Equal(t, StatusFail, report.Status)
+	casecheck.Equal(t, 1, len(report.Checks))
+	casecheck.Equal(t, "db", report.Checks[0].Name)
+	casecheck.Equal(t, "connection refused", report.Checks[0].Error)
+}
+
+func TestUnit_Broker(t *testing.T) {
+	reg := newRegistry(time.Second)
+	b := &broker{reg: reg}
+
+	b.Apply(nil)
+	b.Apply("not a checker")
+	b.Apply(checker{})
+	casecheck.NoError(t, b.OnStart(nil))
+
+	report := reg.Run(context.Background(), All)
+	casecheck.Equal(t, 1, len(report.Checks))
+	casecheck.Equal(t, "mock", report.Checks[0].Name)
+}
+
+type checker struct{}
+
+func (checker) HealthChecks() []Check {
+	return []Check{{Name: "mock", Kind: Readiness, Func: func(_ context.Context) error { return nil }}}
+}
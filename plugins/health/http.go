@@ -0,0 +1,41 @@
+/*
+ *  Copyright (c) 2022-2026 Mikhail Knyazhev <markus621@yandex.com>. All rights reserved.
+ *  Use of this source code is governed by a BSD 3-Clause license that can be found in the LICENSE file.
+ */
+
+package health
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+const (
+	PathHealth    = "/healthz"
+	PathReadiness = "/readyz"
+	PathLiveness  = "/livez"
+)
+
+// Handler runs the checks of the kind and writes the JSON report,
+// the status code is 200 if all checks passed and 503 otherwise
+func Handler(reg Registry, kind Kind) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		report := reg.Run(r.Context(), kind)
+
+		b, err := json.Marshal(report)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		code := http.StatusOK
+		if report.Status != StatusOK {
+			code = http.StatusServiceUnavailable
+		}
+
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.Header().Set("Cache-Control", "no-store")
+		w.WriteHeader(code)
+		_, _ = w.Write(b) //nolint:errcheck
+	}
+}
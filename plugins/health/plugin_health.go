@@ -0,0 +1,63 @@
+/*
+ *  Copyright (c) 2022-2026 Mikhail Knyazhev <markus621@yandex.com>. All rights reserved.
+ *  Use of this source code is governed by a BSD 3-Clause license that can be found in the LICENSE file.
+ */
+
+package health
+
+import (
+	"go.osspkg.com/logx"
+
+	"go.osspkg.com/goppy/v3/pkg/xc"
+	"go.osspkg.com/goppy/v3/plugin"
+)
+
+// WithRegistry adds the health registry, the checks of all objects implementing Checker
+// are registered automatically. The metrics server serves them on /healthz, /readyz and /livez.
+func WithRegistry() plugin.Kind {
+	reg := newRegistry(0)
+	return plugin.Kind{
+		Config: &ConfigGroup{},
+		Inject: []any{
+			func(c *ConfigGroup) Registry {
+				reg.SetTimeout(c.Health.Timeout)
+				return reg
+			},
+			&broker{reg: reg},
+		},
+	}
+}
+
+// broker collects the checks of the plugins
+type broker struct {
+	reg *registry
+}
+
+var _ plugin.Broker = (*broker)(nil)
+
+func (v *broker) Name() string {
+	return "health checks"
+}
+
+// Priority runs before the service broker, so the checks are registered before the servers start
+func (v *broker) Priority() int {
+	return -200
+}
+
+func (v *broker) Apply(arg any) {
+	c, ok := arg.(Checker)
+	if !ok {
+		return
+	}
+	if err := v.reg.Register(c.HealthChecks()...); err != nil {
+		logx.Error("Health", "do", "register checks", "err", err)
+	}
+}
+
+func (v *broker) OnStart(_ xc.Context) error {
+	return nil
+}
+
+func (v *broker) OnStop() error {
+	return nil
+}
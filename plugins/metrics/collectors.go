@@ -0,0 +1,262 @@
+/*
+ *  Copyright (c) 2022-2026 Mikhail Knyazhev <markus621@yandex.com>. All rights reserved.
+ *  Use of this source code is governed by a BSD 3-Clause license that can be found in the LICENSE file.
+ */
+
+package metrics
+
+import (
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"go.osspkg.com/goppy/v3/pkg/xc"
+	"go.osspkg.com/goppy/v3/plugin"
+	"go.osspkg.com/goppy/v3/plugins/orm"
+	"go.osspkg.com/goppy/v3/plugins/orm/metric"
+	"go.osspkg.com/goppy/v3/plugins/web/jsonrpc"
+	"go.osspkg.com/goppy/v3/plugins/ws"
+	"go.osspkg.com/goppy/v3/plugins/xdns"
+)
+
+const (
+	labelName  = "name"
+	labelQType = "qtype"
+	labelRCode = "rcode"
+)
+
+var (
+	dbOpenDesc = prometheus.NewDesc("db_connections_open",
+		"Number of established DB connections both in use and idle.", []string{labelTag}, nil)
+	dbInUseDesc = prometheus.NewDesc("db_connections_in_use",
+		"Number of DB connections currently in use.", []string{labelTag}, nil)
+	dbIdleDesc = prometheus.NewDesc("db_connections_idle",
+		"Number of idle DB connections.", []string{labelTag}, nil)
+	dbMaxOpenDesc = prometheus.NewDesc("db_connections_max_open",
+		"Maximum number of open DB connections.", []string{labelTag}, nil)
+	dbWaitCountDesc = prometheus.NewDesc("db_connections_wait_total",
+		"Total number of DB connections waited for.", []string{labelTag}, nil)
+	dbWaitDurationDesc = prometheus.NewDesc("db_connections_wait_seconds_total",
+		"Total time blocked waiting for a new DB connection.", []string{labelTag}, nil)
+	dbMaxIdleClosedDesc = prometheus.NewDesc("db_connections_max_idle_closed_total",
+		"Total number of DB connections closed due to the idle limit.", []string{labelTag}, nil)
+	dbMaxLifetimeClosedDesc = prometheus.NewDesc("db_connections_max_lifetime_closed_total",
+		"Total number of DB connections closed due to the lifetime limit.", []string{labelTag}, nil)
+
+	wsConnectionsDesc = prometheus.NewDesc("ws_server_connections",
+		"Number of open websocket connections.", nil, nil)
+	wsDroppedDesc = prometheus.NewDesc("ws_server_dropped_messages_total",
+		"Total number of websocket messages dropped for slow consumers.", nil, nil)
+	wsDisconnectedDesc = prometheus.NewDesc("ws_server_disconnected_total",
+		"Total number of websocket slow consumers disconnected.", nil, nil)
+)
+
+// collectors wires the built-in metrics into ORM, websocket, JSON-RPC and DNS
+// when these plugins are loaded together with the metrics plugin
+type collectors struct {
+	orms []orm.ORM
+	wss  []ws.Server
+}
+
+var _ plugin.Broker = (*collectors)(nil)
+
+func newCollectors() *collectors {
+	return &collectors{
+		orms: make([]orm.ORM, 0, 1),
+		wss:  make([]ws.Server, 0, 1),
+	}
+}
+
+func (v *collectors) Name() string {
+	return "metrics collectors"
+}
+
+// Priority runs before the service broker, so observers are set up before the services start
+func (v *collectors) Priority() int {
+	return -200
+}
+
+func (v *collectors) Apply(arg any) {
+	if arg == nil {
+		return
+	}
+	if o, ok := arg.(orm.ORM); ok {
+		v.orms = append(v.orms, o)
+		installQueryWriter()
+	}
+	if s, ok := arg.(ws.Server); ok {
+		v.wss = append(v.wss, s)
+	}
+	if t, ok := arg.(jsonrpc.Transport); ok {
+		t.Observe(observeRPC(registerRPC()))
+	}
+	if s, ok := arg.(*xdns.Server); ok {
+		s.Observe(observeDNS(registerDNS()))
+	}
+}
+
+func (v *collectors) OnStart(_ xc.Context) error {
+	if len(v.orms) == 0 && len(v.wss) == 0 {
+		return nil
+	}
+	return object.prometheus.Register(v)
+}
+
+func (v *collectors) OnStop() error {
+	object.prometheus.Unregister(v)
+	return nil
+}
+
+func (v *collectors) Describe(ch chan<- *prometheus.Desc) {
+	if len(v.orms) > 0 {
+		ch <- dbOpenDesc
+		ch <- dbInUseDesc
+		ch <- dbIdleDesc
+		ch <- dbMaxOpenDesc
+		ch <- dbWaitCountDesc
+		ch <- dbWaitDurationDesc
+		ch <- dbMaxIdleClosedDesc
+		ch <- dbMaxLifetimeClosedDesc
+	}
+	if len(v.wss) > 0 {
+		ch <- wsConnectionsDesc
+		ch <- wsDroppedDesc
+		ch <- wsDisconnectedDesc
+	}
+}
+
+func (v *collectors) Collect(ch chan<- prometheus.Metric) {
+	for _, o := range v.orms {
+		for tag, s := range o.Stats() {
+			ch <- prometheus.MustNewConstMetric(dbOpenDesc, prometheus.GaugeValue, float64(s.OpenConnections), tag)
+			ch <- prometheus.MustNewConstMetric(dbInUseDesc, prometheus.GaugeValue, float64(s.InUse), tag)
+			ch <- prometheus.MustNewConstMetric(dbIdleDesc, prometheus.GaugeValue, float64(s.Idle), tag)
+			ch <- prometheus.MustNewConstMetric(dbMaxOpenDesc, prometheus.GaugeValue, float64(s.MaxOpenConnections), tag)
+			ch <- prometheus.MustNewConstMetric(dbWaitCountDesc, prometheus.CounterValue, float64(s.WaitCount), tag)
+			ch <- prometheus.MustNewConstMetric(dbWaitDurationDesc, prometheus.CounterValue, s.WaitDuration.Seconds(), tag)
+			ch <- prometheus.MustNewConstMetric(dbMaxIdleClosedDesc, prometheus.CounterValue, float64(s.MaxIdleClosed), tag)
+			ch <- prometheus.MustNewConstMetric(dbMaxLifetimeClosedDesc, prometheus.CounterValue, float64(s.MaxLifetimeClosed), tag)
+		}
+	}
+
+	if len(v.wss) > 0 {
+		var conns, dropped, disconnected float64
+		for _, s := range v.wss {
+			stats := s.Stats()
+			conns += float64(s.CountConn())
+			dropped += float64(stats.Dropped)
+			disconnected += float64(stats.Disconnected)
+		}
+		ch <- prometheus.MustNewConstMetric(wsConnectionsDesc, prometheus.GaugeValue, conns)
+		ch <- prometheus.MustNewConstMetric(wsDroppedDesc, prometheus.CounterValue, dropped)
+		ch <- prometheus.MustNewConstMetric(wsDisconnectedDesc, prometheus.CounterValue, disconnected)
+	}
+}
+
+/**********************************************************************************************************************/
+
+var (
+	queryOnce       sync.Once
+	queryWriterOnce sync.Once
+	queryObj        *prometheus.HistogramVec
+)
+
+func registerQuery() *prometheus.HistogramVec {
+	queryOnce.Do(func() {
+		queryObj = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "db_query_duration_seconds",
+			Help:    "Duration of DB queries.",
+			Buckets: prometheus.DefBuckets,
+		}, []string{labelTag, labelName})
+		object.prometheus.MustRegister(queryObj)
+	})
+	return queryObj
+}
+
+// installQueryWriter adds the query metrics to the writer set by the application, it is done once
+// for all the ORMs since the writer is global
+func installQueryWriter() {
+	queryWriterOnce.Do(func() {
+		observe := observeQuery(registerQuery())
+		prev := metric.GetWriter()
+		if prev == nil {
+			metric.SetWriter(observe)
+			return
+		}
+		metric.SetWriter(func(tag, queryName string, execTime time.Duration) {
+			prev(tag, queryName, execTime)
+			observe(tag, queryName, execTime)
+		})
+	})
+}
+
+func observeQuery(m *prometheus.HistogramVec) metric.Writer {
+	return func(tag, queryName string, execTime time.Duration) {
+		m.WithLabelValues(tag, queryName).Observe(execTime.Seconds())
+	}
+}
+
+/**********************************************************************************************************************/
+
+var (
+	rpcOnce sync.Once
+	rpcObj  *rpcMetrics
+)
+
+type rpcMetrics struct {
+	duration *prometheus.HistogramVec
+	errors   *prometheus.CounterVec
+}
+
+func registerRPC() *rpcMetrics {
+	rpcOnce.Do(func() {
+		labels := []string{labelTag, labelMethod}
+		rpcObj = &rpcMetrics{
+			duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+				Name:    "jsonrpc_request_duration_seconds",
+				Help:    "Duration of JSON-RPC method calls.",
+				Buckets: prometheus.DefBuckets,
+			}, labels),
+			errors: prometheus.NewCounterVec(prometheus.CounterOpts{
+				Name: "jsonrpc_errors_total",
+				Help: "Total number of JSON-RPC method calls finished with an error.",
+			}, labels),
+		}
+		object.prometheus.MustRegister(rpcObj.duration, rpcObj.errors)
+	})
+	return rpcObj
+}
+
+func observeRPC(m *rpcMetrics) jsonrpc.ObserveFunc {
+	return func(tag, method string, duration time.Duration, err error) {
+		m.duration.WithLabelValues(tag, method).Observe(duration.Seconds())
+		if err != nil {
+			m.errors.WithLabelValues(tag, method).Inc()
+		}
+	}
+}
+
+/**********************************************************************************************************************/
+
+var (
+	dnsOnce sync.Once
+	dnsObj  *prometheus.CounterVec
+)
+
+func registerDNS() *prometheus.CounterVec {
+	dnsOnce.Do(func() {
+		dnsObj = prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "dns_server_queries_total",
+			Help: "Total number of DNS questions by type and response code.",
+		}, []string{labelQType, labelRCode})
+		object.prometheus.MustRegister(dnsObj)
+	})
+	return dnsObj
+}
+
+func observeDNS(m *prometheus.CounterVec) xdns.ObserveFunc {
+	return func(qtype, rcode string) {
+		m.WithLabelValues(qtype, rcode).Inc()
+	}
+}
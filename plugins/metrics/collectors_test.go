@@ -0,0 +1,61 @@
+/*
+ *  Copyright (c) 2022-2026 Mikhail Knyazhev <markus621@yandex.com>. All rights reserved.
+ *  Use of this source code is governed by a BSD 3-Clause license that can be found in the LICENSE file.
+ */
+
+package metrics
+
+import (
+	"context"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"go.osspkg.com/casecheck"
+
+	"go.osspkg.com/goppy/v3/plugins/orm"
+	"go.osspkg.com/goppy/v3/plugins/orm/clients/sqlite"
+	"go.osspkg.com/goppy/v3/plugins/orm/metric"
+)
+
+func TestUnit_CollectorsORM(t *testing.T) {
+	ctx := context.Background()
+
+	db := orm.New(ctx)
+	defer db.Close()
+
+	casecheck.NoError(t, db.ApplyConfig(sqlite.Name, &sqlite.ConfigGroup{
+		Pool: []sqlite.Config{
+			{Tags: "master", File: filepath.Join(t.TempDir(), "master.db")},
+		},
+	}))
+
+	var appQueries atomic.Int64
+	metric.SetWriter(func(_, _ string, _ time.Duration) { appQueries.Add(1) })
+
+	c := newCollectors()
+	c.Apply(db)
+	casecheck.NoError(t, c.OnStart(nil))
+	defer func() { casecheck.NoError(t, c.OnStop()) }()
+
+	casecheck.NoError(t, db.Tag("master").Exec(ctx, "create", func(q orm.Executor) {
+		q.SQL(`CREATE TABLE "node" ("name" text);`)
+	}))
+
+	casecheck.Equal(t, 1, testutil.CollectAndCount(registerQuery(), "db_query_duration_seconds"))
+	casecheck.Equal(t, int64(1), appQueries.Load())
+	casecheck.Equal(t, 1, testutil.CollectAndCount(c, "db_connections_open"))
+	casecheck.Equal(t, 8, testutil.CollectAndCount(c))
+}
+
+func TestUnit_CollectorsDNS(t *testing.T) {
+	observe := observeDNS(registerDNS())
+	observe("A", "NOERROR")
+	observe("A", "NOERROR")
+	observe("OTHER", "NOERROR")
+
+	casecheck.Equal(t, 2.0, testutil.ToFloat64(registerDNS().WithLabelValues("A", "NOERROR")))
+	casecheck.Equal(t, 1.0, testutil.ToFloat64(registerDNS().WithLabelValues("OTHER", "NOERROR")))
+}
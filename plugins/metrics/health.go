@@ -0,0 +1,69 @@
+/*
+ *  Copyright (c) 2022-2026 Mikhail Knyazhev <markus621@yandex.com>. All rights reserved.
+ *  Use of this source code is governed by a BSD 3-Clause license that can be found in the LICENSE file.
+ */
+
+package metrics
+
+import (
+	"net/http"
+
+	"go.osspkg.com/goppy/v3/pkg/xc"
+	"go.osspkg.com/goppy/v3/plugin"
+	"go.osspkg.com/goppy/v3/plugins/health"
+	"go.osspkg.com/goppy/v3/plugins/web"
+)
+
+// healthEndpoints serves /healthz, /readyz and /livez on the metrics server
+// when the health plugin is loaded together with the metrics plugin
+type healthEndpoints struct {
+	server *Server
+	reg    health.Registry
+}
+
+var _ plugin.Broker = (*healthEndpoints)(nil)
+
+func newHealthEndpoints() *healthEndpoints {
+	return &healthEndpoints{}
+}
+
+func (v *healthEndpoints) Name() string {
+	return "metrics health"
+}
+
+// Priority runs before the service broker, so the handlers are added before the server starts
+func (v *healthEndpoints) Priority() int {
+	return -200
+}
+
+func (v *healthEndpoints) Apply(arg any) {
+	if s, ok := arg.(*Server); ok {
+		v.server = s
+	}
+	if r, ok := arg.(health.Registry); ok {
+		v.reg = r
+	}
+}
+
+func (v *healthEndpoints) OnStart(_ xc.Context) error {
+	if v.server == nil || v.reg == nil {
+		return nil
+	}
+
+	for path, kind := range map[string]health.Kind{
+		health.PathHealth:    health.All,
+		health.PathReadiness: health.Readiness,
+		health.PathLiveness:  health.Liveness,
+	} {
+		handler := health.Handler(v.reg, kind)
+		v.server.AddHandler(path, func(ctx web.Ctx) {
+			handler.ServeHTTP(ctx.Response(), ctx.Request())
+		}, http.MethodGet)
+	}
+
+	return nil
+}
+
+func (v *healthEndpoints) OnStop() error {
+	return nil
+}
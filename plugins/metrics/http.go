@@ -0,0 +1,123 @@
+/*
+ *  Copyright (c) 2022-2026 Mikhail Knyazhev <markus621@yandex.com>. All rights reserved.
+ *  Use of this source code is governed by a BSD 3-Clause license that can be found in the LICENSE file.
+ */
+
+package metrics
+
+import (
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"go.osspkg.com/goppy/v3/plugins/web"
+)
+
+const (
+	labelTag    = "tag"
+	labelMethod = "method"
+	labelRoute  = "route"
+	labelStatus = "status"
+
+	unmatchedRoute = "unmatched"
+	otherMethod    = "other"
+)
+
+var (
+	httpOnce sync.Once
+	httpObj  *httpMetrics
+)
+
+type httpMetrics struct {
+	requests *prometheus.CounterVec
+	duration *prometheus.HistogramVec
+	inFlight *prometheus.GaugeVec
+	size     *prometheus.HistogramVec
+}
+
+func registerHTTP() *httpMetrics {
+	httpOnce.Do(func() {
+		labels := []string{labelTag, labelMethod, labelRoute, labelStatus}
+		httpObj = &httpMetrics{
+			requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+				Namespace: "http",
+				Subsystem: "server",
+				Name:      "requests_total",
+				Help:      "Total number of handled HTTP requests.",
+			}, labels),
+			duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+				Namespace: "http",
+				Subsystem: "server",
+				Name:      "request_duration_seconds",
+				Help:      "Duration of HTTP requests.",
+				Buckets:   prometheus.DefBuckets,
+			}, labels),
+			inFlight: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+				Namespace: "http",
+				Subsystem: "server",
+				Name:      "requests_in_flight",
+				Help:      "Number of HTTP requests being handled.",
+			}, []string{labelTag, labelMethod}),
+			size: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+				Namespace: "http",
+				Subsystem: "server",
+				Name:      "response_size_bytes",
+				Help:      "Size of HTTP response bodies.",
+				Buckets:   prometheus.ExponentialBuckets(64, 4, 8),
+			}, labels),
+		}
+		object.prometheus.MustRegister(httpObj.requests, httpObj.duration, httpObj.inFlight, httpObj.size)
+	})
+	return httpObj
+}
+
+// HTTPMiddleware records count, latency, in-flight requests and response size of the web server,
+// labelled by the server tag, method, route pattern and status class
+//
+//	router.Use(metrics.HTTPMiddleware())
+func HTTPMiddleware() web.Middleware {
+	m := registerHTTP()
+
+	return web.ObserveMiddleware(func(ctx web.Ctx) func(web.ResponseStat) {
+		start := time.Now()
+		tag, method := ctx.ServerTag(), httpMethod(ctx.Request().Method)
+
+		inFlight := m.inFlight.WithLabelValues(tag, method)
+		inFlight.Inc()
+
+		return func(stat web.ResponseStat) {
+			inFlight.Dec()
+
+			route := ctx.RoutePattern()
+			if len(route) == 0 {
+				route = unmatchedRoute
+			}
+
+			lvs := []string{tag, method, route, statusClass(stat.Code)}
+			m.requests.WithLabelValues(lvs...).Inc()
+			m.duration.WithLabelValues(lvs...).Observe(time.Since(start).Seconds())
+			m.size.WithLabelValues(lvs...).Observe(float64(stat.Size))
+		}
+	})
+}
+
+// httpMethod keeps the label cardinality bounded for arbitrary methods
+func httpMethod(method string) string {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodPost, http.MethodPut, http.MethodPatch,
+		http.MethodDelete, http.MethodConnect, http.MethodOptions, http.MethodTrace:
+		return method
+	default:
+		return otherMethod
+	}
+}
+
+func statusClass(code int) string {
+	if code < 100 || code > 599 {
+		return "unknown"
+	}
+	return strconv.Itoa(code/100) + "xx"
+}
@@ -0,0 +1,39 @@
+/*
+ *  Copyright (c) 2022-2026 Mikhail Knyazhev <markus621@yandex.com>. All rights reserved.
+ *  Use of this source code is governed by a BSD 3-Clause license that can be found in the LICENSE file.
+ */
+
+package metrics
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"go.osspkg.com/casecheck"
+
+	"go.osspkg.com/goppy/v3/plugins/web"
+)
+
+func TestUnit_HTTPMiddleware(t *testing.T) {
+	r := web.NewBaseRouter()
+	r.Tag("main")
+	r.Global(HTTPMiddleware())
+	r.Route("/users/{id}", func(ctx web.Ctx) {
+		ctx.String(http.StatusOK, "user")
+	}, http.MethodGet)
+
+	for _, path := range []string{"/users/1", "/users/2", "/users/3", "/unknown"} {
+		r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, path, nil))
+	}
+	r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("PURGE", "/users/1", nil))
+
+	m := registerHTTP()
+	casecheck.Equal(t, 3.0, testutil.ToFloat64(m.requests.WithLabelValues("main", "GET", "/users/{id}", "2xx")))
+	casecheck.Equal(t, 1.0, testutil.ToFloat64(m.requests.WithLabelValues("main", "GET", "unmatched", "4xx")))
+	casecheck.Equal(t, 1.0, testutil.ToFloat64(m.requests.WithLabelValues("main", "other", "unmatched", "4xx")))
+	casecheck.Equal(t, 0.0, testutil.ToFloat64(m.inFlight.WithLabelValues("main", "GET")))
+	casecheck.Equal(t, 3, testutil.CollectAndCount(m.requests))
+	casecheck.Equal(t, 3, testutil.CollectAndCount(m.size))
+}
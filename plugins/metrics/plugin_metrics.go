@@ -41,8 +41,12 @@ func (v *ConfigGroup) Default() {
 func WithServer() plugin.Kind {
 	return plugin.Kind{
 		Config: &ConfigGroup{},
-		Inject: func(ctx xc.Context, app env.AppInfo, c *ConfigGroup) Metrics {
-			return New(ctx, app, c.Config)
+		Inject: []any{
+			func(ctx xc.Context, app env.AppInfo, c *ConfigGroup) Metrics {
+				return New(ctx, app, c.Config)
+			},
+			newCollectors(),
+			newHealthEndpoints(),
 		},
 	}
 }
@@ -21,6 +21,7 @@ var (
 
 type (
 	prom struct {
+		mux          sync.RWMutex
 		prometheus   *prometheus.Registry
 		counter      map[string]prometheus.Counter
 		counterVec   map[string]*prometheus.CounterVec
@@ -67,6 +68,9 @@ func registerAppInfo(app env.AppInfo) {
 }
 
 func registerCounter(app string, opts []string) {
+	object.mux.Lock()
+	defer object.mux.Unlock()
+
 	for _, name := range opts {
 		if _, ok := object.counter[name]; ok {
 			continue
@@ -81,6 +85,9 @@ func registerCounter(app string, opts []string) {
 }
 
 func registerCounterVec(app string, opts map[string][]string) {
+	object.mux.Lock()
+	defer object.mux.Unlock()
+
 	for name, labels := range opts {
 		if _, ok := object.counterVec[name]; ok {
 			continue
@@ -95,6 +102,9 @@ func registerCounterVec(app string, opts map[string][]string) {
 }
 
 func registerGauge(app string, opts []string) {
+	object.mux.Lock()
+	defer object.mux.Unlock()
+
 	for _, name := range opts {
 		if _, ok := object.gauge[name]; ok {
 			continue
@@ -109,6 +119,9 @@ func registerGauge(app string, opts []string) {
 }
 
 func registerGaugeVec(app string, opts map[string][]string) {
+	object.mux.Lock()
+	defer object.mux.Unlock()
+
 	for name, labels := range opts {
 		if _, ok := object.gaugeVec[name]; ok {
 			continue
@@ -123,6 +136,9 @@ func registerGaugeVec(app string, opts map[string][]string) {
 }
 
 func registerHistogram(app string, opts map[string][]float64) {
+	object.mux.Lock()
+	defer object.mux.Unlock()
+
 	for name, buckets := range opts {
 		if _, ok := object.histogram[name]; ok {
 			continue
@@ -136,7 +152,11 @@ func registerHistogram(app string, opts map[string][]float64) {
 		object.prometheus.MustRegister(v)
 	}
 }
+
 func registerHistogramVec(app string, opts map[string]Buckets) {
+	object.mux.Lock()
+	defer object.mux.Unlock()
+
 	for name, opt := range opts {
 		if _, ok := object.histogramVec[name]; ok {
 			continue
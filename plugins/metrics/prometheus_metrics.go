@@ -22,7 +22,9 @@ type CounterInterface interface {
 // A Counter is typically used to count requests served, tasks completed, errors
 // occurred, etc.
 func Counter(name string) CounterInterface {
+	object.mux.RLock()
 	v, ok := object.counter[name]
+	object.mux.RUnlock()
 	if !ok {
 		return fatal("Counter with name `%s` not found. Add to config.", name)
 	}
@@ -35,7 +37,9 @@ func Counter(name string) CounterInterface {
 // (e.g. number of HTTP requests, partitioned by response code and
 // method).
 func CounterVec(name string, labelNameValue ...string) CounterInterface {
+	object.mux.RLock()
 	v, ok := object.counterVec[name]
+	object.mux.RUnlock()
 	if !ok {
 		return fatal("Counter with name `%s` not found. Add to config.", name)
 	}
@@ -73,7 +77,9 @@ type GaugeInterface interface {
 // memory usage, but also "counts" that can go up and down, like the number of
 // running goroutines.
 func Gauge(name string) GaugeInterface {
+	object.mux.RLock()
 	v, ok := object.gauge[name]
+	object.mux.RUnlock()
 	if !ok {
 		return fatal("Gauge with name `%s` not found. Add to config.", name)
 	}
@@ -86,7 +92,9 @@ func Gauge(name string) GaugeInterface {
 // (e.g. number of operations queued, partitioned by user and operation
 // type).
 func GaugeVec(name string, labelNameValue ...string) GaugeInterface {
+	object.mux.RLock()
 	v, ok := object.gaugeVec[name]
+	object.mux.RUnlock()
 	if !ok {
 		return fatal("GaugeVec with name `%s` not found. Add to config.", name)
 	}
@@ -108,7 +116,9 @@ type HistogramInterface interface {
 // experimental Native Histograms, see below for more details). Similar to a
 // Summary, it also provides a sum of observations and an observation count.
 func Histogram(name string) HistogramInterface {
+	object.mux.RLock()
 	v, ok := object.histogram[name]
+	object.mux.RUnlock()
 	if !ok {
 		return fatal("Histogram with name `%s` not found. Add to config.", name)
 	}
@@ -120,7 +130,9 @@ func Histogram(name string) HistogramInterface {
 // if you want to count the same thing partitioned by various dimensions
 // (e.g. HTTP request latencies, partitioned by status code and method).
 func HistogramVec(name string, labelNameValue ...string) HistogramInterface {
+	object.mux.RLock()
 	v, ok := object.histogramVec[name]
+	object.mux.RUnlock()
 	if !ok {
 		return fatal("HistogramVec with name `%s` not found. Add to config.", name)
 	}
@@ -0,0 +1,197 @@
+/*
+ *  Copyright (c) 2022-2026 Mikhail Knyazhev <markus621@yandex.com>. All rights reserved.
+ *  Use of this source code is governed by a BSD 3-Clause license that can be found in the LICENSE file.
+ */
+
+package metrics
+
+import (
+	"fmt"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"go.osspkg.com/errors"
+)
+
+var (
+	ErrMetricExists  = errors.New("metric already registered")
+	ErrMetricInvalid = errors.New("invalid metric")
+)
+
+// Opts description of the metric registered at runtime,
+// the name is used as is without the application namespace
+type Opts struct {
+	Name        string
+	Help        string
+	ConstLabels map[string]string
+}
+
+type (
+	CounterVecInterface interface {
+		// With returns the counter for the label values in the order of the declared labels
+		With(labelValues ...string) CounterInterface
+	}
+	GaugeVecInterface interface {
+		// With returns the gauge for the label values in the order of the declared labels
+		With(labelValues ...string) GaugeInterface
+	}
+	HistogramVecInterface interface {
+		// With returns the histogram for the label values in the order of the declared labels
+		With(labelValues ...string) HistogramInterface
+	}
+)
+
+// NewCounter registers a counter, it is also available by Counter(name)
+func NewCounter(o Opts) (CounterInterface, error) {
+	v := prometheus.NewCounter(prometheus.CounterOpts{
+		Name: o.Name, Help: o.Help, ConstLabels: o.ConstLabels,
+	})
+	if err := register(o.Name, v, func() { object.counter[o.Name] = v }); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// NewCounterVec registers a counter partitioned by labels, it is also available by CounterVec(name, ...)
+func NewCounterVec(o Opts, labels ...string) (CounterVecInterface, error) {
+	v := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: o.Name, Help: o.Help, ConstLabels: o.ConstLabels,
+	}, labels)
+	if err := register(o.Name, v, func() { object.counterVec[o.Name] = v }); err != nil {
+		return nil, err
+	}
+	return counterVec{v: v, name: o.Name}, nil
+}
+
+// NewGauge registers a gauge, it is also available by Gauge(name)
+func NewGauge(o Opts) (GaugeInterface, error) {
+	v := prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: o.Name, Help: o.Help, ConstLabels: o.ConstLabels,
+	})
+	if err := register(o.Name, v, func() { object.gauge[o.Name] = v }); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// NewGaugeVec registers a gauge partitioned by labels, it is also available by GaugeVec(name, ...)
+func NewGaugeVec(o Opts, labels ...string) (GaugeVecInterface, error) {
+	v := prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: o.Name, Help: o.Help, ConstLabels: o.ConstLabels,
+	}, labels)
+	if err := register(o.Name, v, func() { object.gaugeVec[o.Name] = v }); err != nil {
+		return nil, err
+	}
+	return gaugeVec{v: v, name: o.Name}, nil
+}
+
+// NewHistogram registers a histogram, prometheus.DefBuckets are used if buckets are empty,
+// it is also available by Histogram(name)
+func NewHistogram(o Opts, buckets []float64) (HistogramInterface, error) {
+	v := prometheus.NewHistogram(prometheus.HistogramOpts{
+		Name: o.Name, Help: o.Help, ConstLabels: o.ConstLabels, Buckets: buckets,
+	})
+	if err := register(o.Name, v, func() { object.histogram[o.Name] = v }); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// NewHistogramVec registers a histogram partitioned by labels, prometheus.DefBuckets are used if buckets are empty,
+// it is also available by HistogramVec(name, ...)
+func NewHistogramVec(o Opts, buckets []float64, labels ...string) (HistogramVecInterface, error) {
+	v := prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name: o.Name, Help: o.Help, ConstLabels: o.ConstLabels, Buckets: buckets,
+	}, labels)
+	if err := register(o.Name, v, func() { object.histogramVec[o.Name] = v }); err != nil {
+		return nil, err
+	}
+	return histogramVec{v: v, name: o.Name}, nil
+}
+
+// register adds the collector to the registry and stores it by name for the lookup functions,
+// the name must be unique among all kinds of metrics
+func register(name string, c prometheus.Collector, store func()) error {
+	if len(name) == 0 {
+		return errors.Wrapf(ErrMetricInvalid, "empty name")
+	}
+
+	object.mux.Lock()
+	defer object.mux.Unlock()
+
+	if object.has(name) {
+		return errors.Wrapf(ErrMetricExists, "`%s`", name)
+	}
+
+	if err := object.prometheus.Register(c); err != nil {
+		if _, ok := err.(prometheus.AlreadyRegisteredError); ok {
+			return errors.Wrapf(ErrMetricExists, "`%s`", name)
+		}
+		return errors.Wrap(ErrMetricInvalid, fmt.Errorf("`%s`: %w", name, err))
+	}
+
+	store()
+	return nil
+}
+
+func (v *prom) has(name string) bool {
+	if _, ok := v.counter[name]; ok {
+		return true
+	}
+	if _, ok := v.counterVec[name]; ok {
+		return true
+	}
+	if _, ok := v.gauge[name]; ok {
+		return true
+	}
+	if _, ok := v.gaugeVec[name]; ok {
+		return true
+	}
+	if _, ok := v.histogram[name]; ok {
+		return true
+	}
+	if _, ok := v.histogramVec[name]; ok {
+		return true
+	}
+	return false
+}
+
+/**********************************************************************************************************************/
+
+type counterVec struct {
+	v    *prometheus.CounterVec
+	name string
+}
+
+func (c counterVec) With(labelValues ...string) CounterInterface {
+	m, err := c.v.GetMetricWithLabelValues(labelValues...)
+	if err != nil {
+		return fatal("CounterVec `%s`: %s", c.name, err.Error())
+	}
+	return m
+}
+
+type gaugeVec struct {
+	v    *prometheus.GaugeVec
+	name string
+}
+
+func (c gaugeVec) With(labelValues ...string) GaugeInterface {
+	m, err := c.v.GetMetricWithLabelValues(labelValues...)
+	if err != nil {
+		return fatal("GaugeVec `%s`: %s", c.name, err.Error())
+	}
+	return m
+}
+
+type histogramVec struct {
+	v    *prometheus.HistogramVec
+	name string
+}
+
+func (c histogramVec) With(labelValues ...string) HistogramInterface {
+	m, err := c.v.GetMetricWithLabelValues(labelValues...)
+	if err != nil {
+		return fatal("HistogramVec `%s`: %s", c.name, err.Error())
+	}
+	return m
+}
@@ -0,0 +1,58 @@
+/*
+ *  Copyright (c) 2022-2026 Mikhail Knyazhev <markus621@yandex.com>. All rights reserved.
+ *  Use of this source code is governed by a BSD 3-Clause license that can be found in the LICENSE file.
+ */
+
+package metrics
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"go.osspkg.com/casecheck"
+	"go.osspkg.com/errors"
+)
+
+func TestUnit_RegistryCounterVec(t *testing.T) {
+	cv, err := NewCounterVec(Opts{
+		Name:        "registry_test_jobs_total",
+		Help:        "Jobs handled by the test.",
+		ConstLabels: map[string]string{"plugin": "test"},
+	}, "kind")
+	casecheck.NoError(t, err)
+
+	cv.With("a").Inc()
+	cv.With("a").Add(2)
+	CounterVec("registry_test_jobs_total", "kind", "b").Inc()
+
+	_, ok := cv.With("a", "b").(*fatalMessage)
+	casecheck.True(t, ok)
+
+	expected := `
+# HELP registry_test_jobs_total Jobs handled by the test.
+# TYPE registry_test_jobs_total counter
+registry_test_jobs_total{kind="a",plugin="test"} 3
+registry_test_jobs_total{kind="b",plugin="test"} 1
+`
+	casecheck.NoError(t, testutil.GatherAndCompare(object.prometheus, strings.NewReader(expected), "registry_test_jobs_total"))
+}
+
+func TestUnit_RegistryDuplicate(t *testing.T) {
+	g, err := NewGauge(Opts{Name: "registry_test_size", Help: "Size."})
+	casecheck.NoError(t, err)
+	g.Set(5)
+	casecheck.Equal(t, 5.0, testutil.ToFloat64(object.gauge["registry_test_size"]))
+
+	_, err = NewGauge(Opts{Name: "registry_test_size", Help: "Size."})
+	casecheck.True(t, errors.Is(err, ErrMetricExists))
+
+	_, err = NewHistogramVec(Opts{Name: "registry_test_size"}, nil, "kind")
+	casecheck.True(t, errors.Is(err, ErrMetricExists))
+
+	_, err = NewCounter(Opts{})
+	casecheck.True(t, errors.Is(err, ErrMetricInvalid))
+
+	_, err = NewCounterVec(Opts{Name: "registry_test_invalid", ConstLabels: map[string]string{"kind": "a"}}, "kind")
+	casecheck.True(t, errors.Is(err, ErrMetricInvalid))
+}
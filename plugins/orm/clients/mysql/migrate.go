@@ -5,6 +5,15 @@
 
 package mysql
 
+import (
+	"context"
+	"database/sql"
+
+	"go.osspkg.com/errors"
+
+	"go.osspkg.com/goppy/v3/plugins/orm/dialect"
+)
+
 type migrate struct {
 }
 
@@ -13,6 +22,7 @@ func (migrate) CreateTableQuery() []string {
 		"CREATE TABLE `__migrations__` (" +
 			"`id` int unsigned NOT NULL AUTO_INCREMENT PRIMARY KEY," +
 			"`name` text NOT NULL," +
+			"`checksum` varchar(64) NOT NULL DEFAULT ''," +
 			"`timestamp` int unsigned NOT NULL" +
 			") ENGINE='InnoDB';",
 	}
@@ -22,10 +32,67 @@ func (migrate) CheckTableQuery() string {
 	return "SHOW TABLES LIKE '__migrations__';"
 }
 
+func (migrate) CheckChecksumQuery() string {
+	return "SELECT `COLUMN_NAME` FROM `information_schema`.`COLUMNS` " +
+		"WHERE `TABLE_SCHEMA`=DATABASE() AND `TABLE_NAME`='__migrations__' AND `COLUMN_NAME`='checksum';"
+}
+
+func (migrate) UpgradeTableQuery() []string {
+	return []string{
+		"ALTER TABLE `__migrations__` ADD COLUMN `checksum` varchar(64) NOT NULL DEFAULT '' AFTER `name`;",
+	}
+}
+
 func (migrate) CompletedQuery() string {
-	return "SELECT `name` FROM `__migrations__`;"
+	return "SELECT `name`, `timestamp` FROM `__migrations__` ORDER BY `id`;"
+}
+
+func (migrate) ChecksumQuery() string {
+	return "SELECT `name`, `checksum` FROM `__migrations__`;"
 }
 
 func (migrate) SaveQuery() string {
-	return "INSERT INTO `__migrations__` (`name`, `timestamp`) VALUES (?, ?);"
+	return "INSERT INTO `__migrations__` (`name`, `checksum`, `timestamp`) VALUES (?, ?, ?);"
+}
+
+func (migrate) DeleteQuery() string {
+	return "DELETE FROM `__migrations__` WHERE `name`=?;"
+}
+
+// migrateLockExpr name of the user-level lock held while migrating, the locks are server-wide
+// so the name includes the current database, it is limited by 64 chars
+const migrateLockExpr = "LEFT(CONCAT('__migrations__:', IFNULL(DATABASE(), '')), 64)"
+
+func (migrate) Syntax() dialect.Syntax {
+	return dialect.Syntax{BackslashEscape: true}
+}
+
+// Transactional DDL statements cause an implicit commit in mysql
+func (migrate) Transactional() bool {
+	return false
+}
+
+func (migrate) Lock(ctx context.Context, db *sql.DB) (func() error, error) {
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		var locked sql.NullInt64
+		if err = conn.QueryRowContext(ctx, "SELECT GET_LOCK("+migrateLockExpr+", 1);").Scan(&locked); err != nil {
+			return nil, errors.Wrap(err, conn.Close())
+		}
+		if locked.Valid && locked.Int64 == 1 {
+			break
+		}
+		if err = ctx.Err(); err != nil {
+			return nil, errors.Wrap(err, conn.Close())
+		}
+	}
+
+	return func() error {
+		_, err := conn.ExecContext(context.Background(), "SELECT RELEASE_LOCK("+migrateLockExpr+");")
+		return errors.Wrap(err, conn.Close())
+	}, nil
 }
@@ -64,3 +64,9 @@ func (p *pool) Connect(_ context.Context, tag string) (*sql.DB, error) {
 func (p *pool) HasLastInsertId() bool {
 	return false
 }
+
+// ReplicaLagQuery lag is zero on a primary and on a replica that has replayed everything it received
+func (p *pool) ReplicaLagQuery() string {
+	return `SELECT CASE WHEN NOT pg_is_in_recovery() OR pg_last_wal_receive_lsn() = pg_last_wal_replay_lsn() THEN 0 ` +
+		`ELSE COALESCE(EXTRACT(EPOCH FROM now() - pg_last_xact_replay_timestamp()), 0) END;`
+}
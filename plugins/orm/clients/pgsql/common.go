@@ -12,6 +12,7 @@ const Name dialect.Name = "pgsql"
 var (
 	_ dialect.Connector       = (*pool)(nil)
 	_ dialect.ConfigInterface = (*ConfigGroup)(nil)
+	_ dialect.ReplicaLagger   = (*pool)(nil)
 )
 
 func init() {
@@ -5,6 +5,15 @@
 
 package pgsql
 
+import (
+	"context"
+	"database/sql"
+
+	"go.osspkg.com/errors"
+
+	"go.osspkg.com/goppy/v3/plugins/orm/dialect"
+)
+
 type migrate struct {
 }
 
@@ -14,6 +23,7 @@ func (migrate) CreateTableQuery() []string {
 		`CREATE TABLE "__migrations__" (
 			"id" integer DEFAULT nextval('__migrations___id_seq') NOT NULL,
 			"name" text NOT NULL,
+			"checksum" text DEFAULT '' NOT NULL,
 			"timestamp" integer NOT NULL,
 			CONSTRAINT "__migrations___pkey" PRIMARY KEY ("id")
 		) WITH (oids = false);`,
@@ -24,10 +34,56 @@ func (migrate) CheckTableQuery() string {
 	return `SELECT "tablename" FROM "pg_catalog"."pg_tables" WHERE tablename='__migrations__';`
 }
 
+func (migrate) CheckChecksumQuery() string {
+	return `SELECT "column_name" FROM "information_schema"."columns" ` +
+		`WHERE "table_name"='__migrations__' AND "column_name"='checksum';`
+}
+
+func (migrate) UpgradeTableQuery() []string {
+	return []string{
+		`ALTER TABLE "__migrations__" ADD COLUMN "checksum" text DEFAULT '' NOT NULL;`,
+	}
+}
+
 func (migrate) CompletedQuery() string {
-	return `SELECT "name" FROM "__migrations__";`
+	return `SELECT "name", "timestamp" FROM "__migrations__" ORDER BY "id";`
+}
+
+func (migrate) ChecksumQuery() string {
+	return `SELECT "name", "checksum" FROM "__migrations__";`
 }
 
 func (migrate) SaveQuery() string {
-	return `INSERT INTO "__migrations__" ("name", "timestamp") VALUES ($1, $2);`
+	return `INSERT INTO "__migrations__" ("name", "checksum", "timestamp") VALUES ($1, $2, $3);`
+}
+
+func (migrate) DeleteQuery() string {
+	return `DELETE FROM "__migrations__" WHERE "name"=$1;`
+}
+
+// migrateLockKey key of the session advisory lock held while migrating
+const migrateLockKey int64 = 0x676f707079
+
+func (migrate) Syntax() dialect.Syntax {
+	return dialect.Syntax{DollarQuote: true}
+}
+
+func (migrate) Transactional() bool {
+	return true
+}
+
+func (migrate) Lock(ctx context.Context, db *sql.DB) (func() error, error) {
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err = conn.ExecContext(ctx, `SELECT pg_advisory_lock($1);`, migrateLockKey); err != nil {
+		return nil, errors.Wrap(err, conn.Close())
+	}
+
+	return func() error {
+		_, err := conn.ExecContext(context.Background(), `SELECT pg_advisory_unlock($1);`, migrateLockKey)
+		return errors.Wrap(err, conn.Close())
+	}, nil
 }
@@ -5,6 +5,14 @@
 
 package sqlite
 
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"go.osspkg.com/goppy/v3/plugins/orm/dialect"
+)
+
 type migrate struct {
 }
 
@@ -13,6 +21,7 @@ func (migrate) CreateTableQuery() []string {
 		`CREATE TABLE "__migrations__" (
   			"id" integer NOT NULL PRIMARY KEY AUTOINCREMENT,
   			"name" text NOT NULL,
+  			"checksum" text NOT NULL DEFAULT '',
   			"timestamp" integer NOT NULL
 		);`,
 	}
@@ -22,10 +31,87 @@ func (migrate) CheckTableQuery() string {
 	return `SELECT "name" FROM "sqlite_master" WHERE "type"='table' AND "name"='__migrations__';`
 }
 
+func (migrate) CheckChecksumQuery() string {
+	return `SELECT "name" FROM pragma_table_info('__migrations__') WHERE "name"='checksum';`
+}
+
+func (migrate) UpgradeTableQuery() []string {
+	return []string{
+		`ALTER TABLE "__migrations__" ADD COLUMN "checksum" text NOT NULL DEFAULT '';`,
+	}
+}
+
 func (migrate) CompletedQuery() string {
-	return `SELECT "name" FROM "__migrations__";`
+	return `SELECT "name", "timestamp" FROM "__migrations__" ORDER BY "id";`
+}
+
+func (migrate) ChecksumQuery() string {
+	return `SELECT "name", "checksum" FROM "__migrations__";`
 }
 
 func (migrate) SaveQuery() string {
-	return `INSERT INTO "__migrations__" ("name", "timestamp") VALUES (?, ?);`
+	return `INSERT INTO "__migrations__" ("name", "checksum", "timestamp") VALUES (?, ?, ?);`
+}
+
+func (migrate) DeleteQuery() string {
+	return `DELETE FROM "__migrations__" WHERE "name"=?;`
+}
+
+const (
+	migrateLockTTL      = time.Minute * 30
+	migrateLockInterval = time.Millisecond * 100
+)
+
+func (migrate) Syntax() dialect.Syntax {
+	return dialect.Syntax{}
+}
+
+func (migrate) Transactional() bool {
+	return true
+}
+
+// Lock uses a lock table, a lock older than migrateLockTTL is treated as left by a crashed process
+func (migrate) Lock(ctx context.Context, db *sql.DB) (func() error, error) {
+	_, err := db.ExecContext(ctx, `CREATE TABLE IF NOT EXISTS "__migrations_lock__" (
+  			"id" integer NOT NULL PRIMARY KEY,
+  			"timestamp" integer NOT NULL
+		);`)
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		now := time.Now()
+		_, err = db.ExecContext(ctx, `DELETE FROM "__migrations_lock__" WHERE "timestamp" < ?;`,
+			now.Add(-migrateLockTTL).Unix())
+		if err != nil {
+			return nil, err
+		}
+
+		var result sql.Result
+		result, err = db.ExecContext(ctx, `INSERT OR IGNORE INTO "__migrations_lock__" ("id", "timestamp") VALUES (1, ?);`,
+			now.Unix())
+		if err != nil {
+			return nil, err
+		}
+
+		var n int64
+		if n, err = result.RowsAffected(); err != nil {
+			return nil, err
+		}
+		if n == 1 {
+			break
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(migrateLockInterval):
+		}
+	}
+
+	return func() error {
+		_, err := db.ExecContext(context.Background(), `DELETE FROM "__migrations_lock__" WHERE "id"=1;`)
+		return err
+	}, nil
 }
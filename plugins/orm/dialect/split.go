@@ -0,0 +1,123 @@
+/*
+ *  Copyright (c) 2022-2026 Mikhail Knyazhev <markus621@yandex.com>. All rights reserved.
+ *  Use of this source code is governed by a BSD 3-Clause license that can be found in the LICENSE file.
+ */
+
+package dialect
+
+import (
+	"strings"
+)
+
+// Syntax describes the SQL lexical features that affect statement splitting
+type Syntax struct {
+	// DollarQuote enables $tag$ ... $tag$ quoted bodies (pgsql)
+	DollarQuote bool
+	// BackslashEscape enables \' escapes inside string literals (mysql)
+	BackslashEscape bool
+}
+
+// SplitSQL splits a script into statements by `;`, ignoring semicolons inside
+// string literals, quoted identifiers, comments and dollar-quoted bodies.
+// Line comments are removed, empty statements are skipped.
+func SplitSQL(data string, syntax Syntax) []string {
+	result := make([]string, 0, 4)
+	curr := strings.Builder{}
+
+	flush := func() {
+		if s := strings.TrimSpace(curr.String()); len(s) > 0 {
+			result = append(result, s)
+		}
+		curr.Reset()
+	}
+
+	for i := 0; i < len(data); i++ {
+		c := data[i]
+
+		switch {
+		case c == ';':
+			flush()
+
+		case c == '-' && i+1 < len(data) && data[i+1] == '-':
+			end := strings.IndexByte(data[i:], '\n')
+			if end < 0 {
+				i = len(data)
+				continue
+			}
+			i += end
+			curr.WriteByte('\n')
+
+		case c == '/' && i+1 < len(data) && data[i+1] == '*':
+			end := strings.Index(data[i+2:], "*/")
+			if end < 0 {
+				curr.WriteString(data[i:])
+				i = len(data)
+				continue
+			}
+			curr.WriteString(data[i : i+2+end+2])
+			i += 2 + end + 1
+
+		case c == '\'' || c == '"' || c == '`':
+			end := quoteEnd(data, i, c, syntax.BackslashEscape && c != '`')
+			curr.WriteString(data[i:end])
+			i = end - 1
+
+		case c == '$' && syntax.DollarQuote:
+			tag, ok := dollarTag(data[i:])
+			if !ok {
+				curr.WriteByte(c)
+				continue
+			}
+			end := strings.Index(data[i+len(tag):], tag)
+			if end < 0 {
+				curr.WriteString(data[i:])
+				i = len(data)
+				continue
+			}
+			end = i + len(tag) + end + len(tag)
+			curr.WriteString(data[i:end])
+			i = end - 1
+
+		default:
+			curr.WriteByte(c)
+		}
+	}
+	flush()
+
+	return result
+}
+
+// quoteEnd returns the index after the closing quote, doubled quotes are treated as escaped
+func quoteEnd(data string, start int, quote byte, backslash bool) int {
+	for i := start + 1; i < len(data); i++ {
+		switch data[i] {
+		case '\\':
+			if backslash {
+				i++
+			}
+		case quote:
+			if i+1 < len(data) && data[i+1] == quote {
+				i++
+				continue
+			}
+			return i + 1
+		}
+	}
+	return len(data)
+}
+
+// dollarTag returns the opening tag like `$$` or `$body$`, positional params like `$1` are not tags
+func dollarTag(data string) (string, bool) {
+	for i := 1; i < len(data); i++ {
+		c := data[i]
+		switch {
+		case c == '$':
+			return data[:i+1], true
+		case c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z'):
+		case c >= '0' && c <= '9' && i > 1:
+		default:
+			return "", false
+		}
+	}
+	return "", false
+}
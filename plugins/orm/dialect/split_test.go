@@ -0,0 +1,71 @@
+/*
+ *  Copyright (c) 2022-2026 Mikhail Knyazhev <markus621@yandex.com>. All rights reserved.
+ *  Use of this source code is governed by a BSD 3-Clause license that can be found in the LICENSE file.
+ */
+
+package dialect
+
+import (
+	"testing"
+
+	"go.osspkg.com/casecheck"
+)
+
+func TestUnit_SplitSQL(t *testing.T) {
+	tests := []struct {
+		name   string
+		syntax Syntax
+		arg    string
+		want   []string
+	}{
+		{
+			name: "Case1",
+			arg:  "-- SEQUENCE\nCREATE SEQUENCE IF",
+			want: []string{"CREATE SEQUENCE IF"},
+		},
+		{
+			name: "Case2",
+			arg:  "\n-- SEQUENCE\n-- SEQUENCE\n-- SEQUENCE\n\nCREATE SEQUENCE IF",
+			want: []string{"CREATE SEQUENCE IF"},
+		},
+		{
+			name: "Case3",
+			arg:  "INSERT INTO a VALUES ('x;y', 'it''s -- ok');;\n INSERT INTO \"b;c\" VALUES (1); -- tail;",
+			want: []string{
+				"INSERT INTO a VALUES ('x;y', 'it''s -- ok')",
+				"INSERT INTO \"b;c\" VALUES (1)",
+			},
+		},
+		{
+			name: "Case4",
+			arg:  "/* a; b */ SELECT 1; SELECT `x;y` FROM t",
+			want: []string{"/* a; b */ SELECT 1", "SELECT `x;y` FROM t"},
+		},
+		{
+			name:   "Case5",
+			syntax: Syntax{DollarQuote: true},
+			arg: "CREATE FUNCTION f() RETURNS trigger AS $body$ BEGIN NEW.a := 1; RETURN NEW; END; $body$ LANGUAGE plpgsql;\n" +
+				"SELECT $$a;b$$, $1;",
+			want: []string{
+				"CREATE FUNCTION f() RETURNS trigger AS $body$ BEGIN NEW.a := 1; RETURN NEW; END; $body$ LANGUAGE plpgsql",
+				"SELECT $$a;b$$, $1",
+			},
+		},
+		{
+			name: "Case6",
+			arg:  "SELECT $$a;b$$",
+			want: []string{"SELECT $$a", "b$$"},
+		},
+		{
+			name:   "Case7",
+			syntax: Syntax{BackslashEscape: true},
+			arg:    `INSERT INTO a VALUES ('x\';y'); SELECT 1`,
+			want:   []string{`INSERT INTO a VALUES ('x\';y')`, "SELECT 1"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			casecheck.Equal(t, tt.want, SplitSQL(tt.arg, tt.syntax))
+		})
+	}
+}
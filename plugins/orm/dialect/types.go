@@ -45,11 +45,26 @@ type (
 		CastTypesFunc() func(args []any)
 		HasLastInsertId() bool
 	}
+	// ReplicaLagger optional Connector interface to measure replication lag
+	ReplicaLagger interface {
+		// ReplicaLagQuery returns lag of the replica in seconds, NULL or 0 on a primary
+		ReplicaLagQuery() string
+	}
 	// Migrator interface of migration
 	Migrator interface {
 		CreateTableQuery() []string
 		CheckTableQuery() string
+		CheckChecksumQuery() string
+		UpgradeTableQuery() []string
 		CompletedQuery() string
+		ChecksumQuery() string
 		SaveQuery() string
+		DeleteQuery() string
+		// Syntax of migration scripts for splitting into statements
+		Syntax() Syntax
+		// Transactional reports whether a migration file can run in one transaction
+		Transactional() bool
+		// Lock blocks until the migration lock is taken, the returned func releases it
+		Lock(ctx context.Context, db *sql.DB) (unlock func() error, err error)
 	}
 )
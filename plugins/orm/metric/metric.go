@@ -21,6 +21,14 @@ func SetWriter(w Writer) {
 	store.Store(w)
 }
 
+// GetWriter returns the current writer, nil if it is not set
+func GetWriter() Writer {
+	if w, ok := store.Load().(Writer); ok {
+		return w
+	}
+	return nil
+}
+
 func ExecTime(tag, name string, call func()) {
 	curr := time.Now()
 	call()
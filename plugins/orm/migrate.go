@@ -6,10 +6,14 @@
 package orm
 
 import (
+	"cmp"
 	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
 	"fmt"
 	"path/filepath"
-	"regexp"
+	"slices"
 	"strings"
 	"sync"
 	"time"
@@ -20,15 +24,85 @@ import (
 	"go.osspkg.com/goppy/v3/plugins/orm/dialect"
 )
 
+var (
+	ErrMigrationModified = errors.New("applied migration was modified")
+	ErrMigrationNoDown   = errors.New("migration has no down file")
+	ErrMigrationMissing  = errors.New("applied migration file not found")
+	ErrMigrationSteps    = errors.New("rollback steps must be greater than 0")
+)
+
+const (
+	migrationUpSuffix   = ".up.sql"
+	migrationDownSuffix = ".down.sql"
+)
+
+type MigrationStatus string
+
+const (
+	// MigrationApplied migration is recorded in the migration table and the file is unchanged
+	MigrationApplied MigrationStatus = "applied"
+	// MigrationPending migration file is not applied yet
+	MigrationPending MigrationStatus = "pending"
+	// MigrationModified migration is applied, but the file checksum differs from the stored one
+	MigrationModified MigrationStatus = "modified"
+	// MigrationMissing migration is applied, but the file is not found
+	MigrationMissing MigrationStatus = "missing"
+)
+
+// MigrationState describes one migration for a dialect and tag
+type MigrationState struct {
+	Dialect    dialect.Name
+	Tag        string
+	Name       string
+	Checksum   string
+	Status     MigrationStatus
+	Reversible bool
+	AppliedAt  time.Time
+}
+
 type Migrator interface {
+	// Run applies all pending migrations
 	Run(ctx context.Context) error
+	// Rollback reverts the last n applied migrations for every tag, n must be greater than 0
+	Rollback(ctx context.Context, n int) error
+	// Plan returns the migrations that Run would apply, without changing the database
+	Plan(ctx context.Context) ([]MigrationState, error)
+	// RollbackPlan returns the migrations that Rollback would revert, without changing the database
+	RollbackPlan(ctx context.Context, n int) ([]MigrationState, error)
+	// Status returns applied, pending, modified and missing migrations
+	Status(ctx context.Context) ([]MigrationState, error)
 }
 
-type migrate struct {
-	conn ORM
-	fs   FS
-	mux  sync.Mutex
-}
+type (
+	migrate struct {
+		conn ORM
+		fs   FS
+		mux  sync.Mutex
+	}
+
+	migrationFile struct {
+		Name     string
+		Up       string
+		Down     string
+		Data     string
+		Checksum string
+	}
+
+	migrationRecord struct {
+		Name      string
+		Checksum  string
+		Timestamp int64
+		Order     int64
+	}
+
+	migrationTarget struct {
+		Dialect dialect.Name
+		Tag     string
+		Stmt    Stmt
+		Mig     dialect.Migrator
+		Files   []migrationFile
+	}
+)
 
 func NewMigrate(o ORM, fs FS) Migrator {
 	return &migrate{
@@ -38,29 +112,157 @@ func NewMigrate(o ORM, fs FS) Migrator {
 }
 
 func (v *migrate) Run(ctx context.Context) error {
-	return v.executor(ctx,
-		func(dialectName dialect.Name, stmt Stmt, mig dialect.Migrator) (map[string]struct{}, error) {
-			if !migrateTableCheck(ctx, stmt, mig.CheckTableQuery()) {
-				for _, createQuery := range mig.CreateTableQuery() {
-					if err := migrateCreateTable(ctx, stmt, createQuery); err != nil {
-						return nil, fmt.Errorf("create table for '%s': %w", dialectName, err)
-					}
-				}
+	return v.executor(func(t migrationTarget) error {
+		unlock, err := migrateLock(ctx, t)
+		if err != nil {
+			return err
+		}
+		defer unlock()
+
+		records, err := migrateRecords(ctx, t.Stmt, t.Mig, true)
+		if err != nil {
+			return fmt.Errorf("get completed migration for tag '%s:%s': %w", t.Dialect, t.Tag, err)
+		}
+
+		states := migrateStates(t, records)
+		for _, state := range states {
+			if state.Status == MigrationModified {
+				return errors.Wrapf(ErrMigrationModified, "'%s' for tag '%s:%s'", state.Name, t.Dialect, t.Tag)
 			}
+		}
 
-			return migrateCompletedList(ctx, stmt, mig.CompletedQuery())
-		},
-		func(name string, stmt Stmt, mig dialect.Migrator) error {
-			return migrateSave(ctx, stmt, mig.SaveQuery(), name)
-		},
-	)
+		for _, file := range t.Files {
+			if _, ok := records[file.Name]; ok {
+				continue
+			}
+
+			err = migrateApply(ctx, t, "new migration", file.Data, func(ctx context.Context, db DB) error {
+				return migrateSave(ctx, db, t.Mig.SaveQuery(), file.Name, file.Checksum)
+			})
+			if err != nil {
+				logx.Error("New DB migration", "dialect", t.Dialect, "tag", t.Tag,
+					"file", file.Up, "err", err)
+
+				return errors.Wrapf(err, "exec migration file '%s'", file.Name)
+			}
+
+			logx.Info("New DB migration", "dialect", t.Dialect, "tag", t.Tag, "file", file.Up)
+		}
+
+		return nil
+	})
 }
 
-func (v *migrate) executor(
-	ctx context.Context,
-	getExists func(dialectName dialect.Name, stmt Stmt, mig dialect.Migrator) (map[string]struct{}, error),
-	saveNew func(name string, stmt Stmt, mig dialect.Migrator) error,
-) error {
+func (v *migrate) Rollback(ctx context.Context, n int) error {
+	if n < 1 {
+		return errors.Wrapf(ErrMigrationSteps, "got %d", n)
+	}
+	return v.executor(func(t migrationTarget) error {
+		unlock, err := migrateLock(ctx, t)
+		if err != nil {
+			return err
+		}
+		defer unlock()
+
+		records, err := migrateRecords(ctx, t.Stmt, t.Mig, false)
+		if err != nil {
+			return fmt.Errorf("get completed migration for tag '%s:%s': %w", t.Dialect, t.Tag, err)
+		}
+
+		list, err := migrateRollbackList(t, records, n)
+		if err != nil {
+			return err
+		}
+
+		for _, file := range list {
+			data, err := v.fs.FileData(file.Down)
+			if err != nil {
+				return fmt.Errorf("read migration file '%s' for tag '%s:%s': %w", file.Down, t.Dialect, t.Tag, err)
+			}
+
+			err = migrateApply(ctx, t, "rollback migration", data, func(ctx context.Context, db DB) error {
+				return migrateDelete(ctx, db, t.Mig.DeleteQuery(), file.Name)
+			})
+			if err != nil {
+				logx.Error("Rollback DB migration", "dialect", t.Dialect, "tag", t.Tag,
+					"file", file.Down, "err", err)
+
+				return errors.Wrapf(err, "exec migration file '%s'", filepath.Base(file.Down))
+			}
+
+			logx.Info("Rollback DB migration", "dialect", t.Dialect, "tag", t.Tag, "file", file.Down)
+		}
+
+		return nil
+	})
+}
+
+func (v *migrate) Plan(ctx context.Context) ([]MigrationState, error) {
+	result := make([]MigrationState, 0)
+	err := v.executor(func(t migrationTarget) error {
+		records, err := migrateRecords(ctx, t.Stmt, t.Mig, false)
+		if err != nil {
+			return fmt.Errorf("get completed migration for tag '%s:%s': %w", t.Dialect, t.Tag, err)
+		}
+
+		for _, state := range migrateStates(t, records) {
+			if state.Status == MigrationPending {
+				result = append(result, state)
+			}
+		}
+		return nil
+	})
+	return result, err
+}
+
+func (v *migrate) RollbackPlan(ctx context.Context, n int) ([]MigrationState, error) {
+	if n < 1 {
+		return nil, errors.Wrapf(ErrMigrationSteps, "got %d", n)
+	}
+	result := make([]MigrationState, 0)
+	err := v.executor(func(t migrationTarget) error {
+		records, err := migrateRecords(ctx, t.Stmt, t.Mig, false)
+		if err != nil {
+			return fmt.Errorf("get completed migration for tag '%s:%s': %w", t.Dialect, t.Tag, err)
+		}
+
+		list, err := migrateRollbackList(t, records, n)
+		if err != nil {
+			return err
+		}
+
+		for _, file := range list {
+			record := records[file.Name]
+			result = append(result, MigrationState{
+				Dialect:    t.Dialect,
+				Tag:        t.Tag,
+				Name:       file.Name,
+				Checksum:   record.Checksum,
+				Status:     MigrationApplied,
+				Reversible: true,
+				AppliedAt:  time.Unix(record.Timestamp, 0),
+			})
+		}
+		return nil
+	})
+	return result, err
+}
+
+func (v *migrate) Status(ctx context.Context) ([]MigrationState, error) {
+	result := make([]MigrationState, 0)
+	err := v.executor(func(t migrationTarget) error {
+		records, err := migrateRecords(ctx, t.Stmt, t.Mig, false)
+		if err != nil {
+			return fmt.Errorf("get completed migration for tag '%s:%s': %w", t.Dialect, t.Tag, err)
+		}
+
+		result = append(result, migrateStates(t, records)...)
+		return nil
+	})
+	return result, err
+}
+
+func (v *migrate) executor(call func(t migrationTarget) error) error {
 	v.mux.Lock()
 	defer v.mux.Unlock()
 
@@ -74,67 +276,242 @@ func (v *migrate) executor(
 			continue
 		}
 
-		for _, tag := range v.fs.Tags() {
+		files, err := v.files()
+		if err != nil {
+			return fmt.Errorf("get migration files for dialect '%s': %w", dialectName, err)
+		}
 
-			stmt := v.conn.Tag(tag)
-			exist, err := getExists(dialectName, stmt, mig)
+		for _, tag := range v.fs.Tags() {
+			err = call(migrationTarget{
+				Dialect: dialectName,
+				Tag:     tag,
+				Stmt:    v.conn.Tag(tag),
+				Mig:     mig,
+				Files:   files,
+			})
 			if err != nil {
-				return fmt.Errorf("get completed migration for tag '%s:%s': %w", dialectName, tag, err)
+				return err
 			}
+		}
 
-			list, err := v.fs.FileNames()
-			if err != nil {
-				return fmt.Errorf("get migration files for tag '%s:%s': %w", dialectName, tag, err)
+	}
+	return nil
+}
+
+// files groups the current migration files into up/down pairs.
+// A plain `name.sql` file is a forward-only migration, `name.up.sql`
+// and `name.down.sql` make a reversible one.
+func (v *migrate) files() ([]migrationFile, error) {
+	list, err := v.fs.FileNames()
+	if err != nil {
+		return nil, err
+	}
+
+	downs := make(map[string]string, len(list))
+	for _, filePath := range list {
+		name := filepath.Base(filePath)
+		if strings.HasSuffix(name, migrationDownSuffix) {
+			downs[strings.TrimSuffix(name, migrationDownSuffix)] = filePath
+		}
+	}
+
+	result := make([]migrationFile, 0, len(list))
+	for _, filePath := range list {
+		name := filepath.Base(filePath)
+		if strings.HasSuffix(name, migrationDownSuffix) {
+			continue
+		}
+
+		rawData, err := v.fs.FileData(filePath)
+		if err != nil {
+			return nil, fmt.Errorf("read migration file '%s': %w", name, err)
+		}
+
+		file := migrationFile{
+			Name:     name,
+			Up:       filePath,
+			Data:     rawData,
+			Checksum: migrateChecksum(rawData),
+		}
+
+		if strings.HasSuffix(name, migrationUpSuffix) {
+			file.Down = downs[strings.TrimSuffix(name, migrationUpSuffix)]
+		}
+
+		result = append(result, file)
+	}
+
+	return result, nil
+}
+
+func migrateChecksum(data string) string {
+	h := sha256.Sum256([]byte(data))
+	return hex.EncodeToString(h[:])
+}
+
+func migrateStates(t migrationTarget, records map[string]migrationRecord) []MigrationState {
+	result := make([]MigrationState, 0, len(t.Files)+len(records))
+	exist := make(map[string]struct{}, len(t.Files))
+
+	for _, file := range t.Files {
+		exist[file.Name] = struct{}{}
+
+		state := MigrationState{
+			Dialect:    t.Dialect,
+			Tag:        t.Tag,
+			Name:       file.Name,
+			Checksum:   file.Checksum,
+			Status:     MigrationPending,
+			Reversible: len(file.Down) > 0,
+		}
+
+		if record, ok := records[file.Name]; ok {
+			state.Status = MigrationApplied
+			state.AppliedAt = time.Unix(record.Timestamp, 0)
+			// records saved before checksums were stored cannot be verified
+			if len(record.Checksum) > 0 && record.Checksum != file.Checksum {
+				state.Status = MigrationModified
 			}
+		}
 
-			for _, filePath := range list {
-				name := filepath.Base(filePath)
-				if _, ok := exist[name]; ok {
-					continue
-				}
+		result = append(result, state)
+	}
 
-				rawData, err := v.fs.FileData(filePath)
-				if err != nil {
-					logx.Error("New DB migration", "dialect", dialectName, "tag", tag,
-						"file", filePath, "err", err)
+	for _, record := range migrateRecordList(records) {
+		if _, ok := exist[record.Name]; ok {
+			continue
+		}
+		result = append(result, MigrationState{
+			Dialect:   t.Dialect,
+			Tag:       t.Tag,
+			Name:      record.Name,
+			Checksum:  record.Checksum,
+			Status:    MigrationMissing,
+			AppliedAt: time.Unix(record.Timestamp, 0),
+		})
+	}
 
-					return fmt.Errorf("read migration file '%s' for tag '%s:%s': %w ", name, dialectName, tag, err)
-				}
+	return result
+}
 
-				for _, subsql := range strings.Split(rawData, ";") {
-					subsql = removeSQLComment(subsql)
-					if len(subsql) == 0 {
-						continue
-					}
+// migrateRollbackList returns the last n applied migrations, newest first
+func migrateRollbackList(t migrationTarget, records map[string]migrationRecord, n int) ([]migrationFile, error) {
+	files := make(map[string]migrationFile, len(t.Files))
+	for _, file := range t.Files {
+		files[file.Name] = file
+	}
 
-					if err = stmt.Exec(ctx, "new migration", func(q Executor) { q.SQL(subsql) }); err != nil {
-						logx.Error("New DB migration", "dialect", dialectName, "tag", tag,
-							"file", filePath, "sql", subsql, "err", err)
+	applied := migrateRecordList(records)
+	result := make([]migrationFile, 0, n)
 
-						return errors.Wrapf(err, "exec migration file '%s', sql: '%s'", name, subsql)
-					}
-				}
+	for i := len(applied) - 1; i >= 0 && len(result) < n; i-- {
+		file, ok := files[applied[i].Name]
+		if !ok {
+			return nil, errors.Wrapf(ErrMigrationMissing, "'%s' for tag '%s:%s'", applied[i].Name, t.Dialect, t.Tag)
+		}
+		if len(file.Down) == 0 {
+			return nil, errors.Wrapf(ErrMigrationNoDown, "'%s' for tag '%s:%s'", file.Name, t.Dialect, t.Tag)
+		}
+		result = append(result, file)
+	}
 
-				if err = saveNew(name, stmt, mig); err != nil {
-					logx.Error("New DB migration", "dialect", dialectName, "tag", tag,
-						"file", filePath, "err", err)
+	return result, nil
+}
 
-					return errors.Wrapf(err, "save migrated file '%s'", name)
-				}
+// migrateApply runs the statements of a migration file followed by the migration table update,
+// in one transaction when the dialect supports transactional DDL
+func migrateApply(ctx context.Context, t migrationTarget, name, rawData string,
+	done func(ctx context.Context, db DB) error) error {
+	queries := dialect.SplitSQL(rawData, t.Mig.Syntax())
 
-				logx.Info("New DB migration", "dialect", dialectName, "tag", tag, "file", filePath)
+	call := func(ctx context.Context, db DB) error {
+		for _, query := range queries {
+			if _, err := db.ExecContext(ctx, query); err != nil {
+				return errors.Wrapf(err, "sql: '%s'", query)
 			}
 		}
+		return done(ctx, db)
+	}
 
+	if t.Mig.Transactional() {
+		return t.Stmt.TxContext(ctx, name, call)
 	}
-	return nil
+	return t.Stmt.CallContext(ctx, name, call)
 }
 
-var sqlCommentRex = regexp.MustCompile(`(?mU)--.*\n`)
+// migrateLock takes the dialect migration lock, the returned func releases it
+func migrateLock(ctx context.Context, t migrationTarget) (func(), error) {
+	var unlock func() error
+	err := t.Stmt.CallContext(ctx, "migration lock", func(ctx context.Context, db DB) error {
+		conn, ok := db.(*sql.DB)
+		if !ok {
+			return fmt.Errorf("unsupported connection type %T", db)
+		}
+
+		var err error
+		unlock, err = t.Mig.Lock(ctx, conn)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("lock migration for tag '%s:%s': %w", t.Dialect, t.Tag, err)
+	}
 
-func removeSQLComment(s string) string {
-	s = sqlCommentRex.ReplaceAllString(s, "\n")
-	return strings.TrimSpace(s)
+	return func() {
+		if err := unlock(); err != nil {
+			logx.Error("Unlock DB migration", "dialect", t.Dialect, "tag", t.Tag, "err", err)
+		}
+	}, nil
+}
+
+// migrateRecords reads the migration table. With prepare the table is created
+// or upgraded when needed, otherwise the database is left untouched.
+func migrateRecords(ctx context.Context, stmt Stmt, mig dialect.Migrator, prepare bool) (map[string]migrationRecord, error) {
+	if !migrateTableCheck(ctx, stmt, mig.CheckTableQuery()) {
+		if !prepare {
+			return map[string]migrationRecord{}, nil
+		}
+		for _, createQuery := range mig.CreateTableQuery() {
+			if err := migrateCreateTable(ctx, stmt, createQuery); err != nil {
+				return nil, fmt.Errorf("create table: %w", err)
+			}
+		}
+	}
+
+	hasChecksum := migrateTableCheck(ctx, stmt, mig.CheckChecksumQuery())
+	if !hasChecksum && prepare {
+		for _, upgradeQuery := range mig.UpgradeTableQuery() {
+			if err := migrateCreateTable(ctx, stmt, upgradeQuery); err != nil {
+				return nil, fmt.Errorf("upgrade table: %w", err)
+			}
+		}
+		hasChecksum = true
+	}
+
+	list, err := migrateCompletedList(ctx, stmt, mig.CompletedQuery())
+	if err != nil {
+		return nil, err
+	}
+
+	if !hasChecksum {
+		return list, nil
+	}
+
+	if err = migrateChecksumList(ctx, stmt, mig.ChecksumQuery(), list); err != nil {
+		return nil, err
+	}
+
+	return list, nil
+}
+
+func migrateRecordList(records map[string]migrationRecord) []migrationRecord {
+	result := make([]migrationRecord, 0, len(records))
+	for _, record := range records {
+		result = append(result, record)
+	}
+	slices.SortFunc(result, func(a, b migrationRecord) int {
+		return cmp.Compare(a.Order, b.Order)
+	})
+	return result
 }
 
 func migrateTableCheck(ctx context.Context, stmt Stmt, query string) bool {
@@ -162,16 +539,19 @@ func migrateCreateTable(ctx context.Context, stmt Stmt, query string) error {
 	})
 }
 
-func migrateCompletedList(ctx context.Context, stmt Stmt, query string) (map[string]struct{}, error) {
-	list := make(map[string]struct{}, 0)
+func migrateCompletedList(ctx context.Context, stmt Stmt, query string) (map[string]migrationRecord, error) {
+	list := make(map[string]migrationRecord, 0)
+	index := int64(0)
 	err := stmt.Query(ctx, "list migrations", func(q Querier) {
 		q.SQL(query)
 		q.Bind(func(bind Scanner) error {
-			var name string
-			if err := bind.Scan(&name); err != nil {
+			var record migrationRecord
+			if err := bind.Scan(&record.Name, &record.Timestamp); err != nil {
 				return err
 			}
-			list[name] = struct{}{}
+			index++
+			record.Order = index
+			list[record.Name] = record
 			return nil
 		})
 	})
@@ -181,14 +561,41 @@ func migrateCompletedList(ctx context.Context, stmt Stmt, query string) (map[str
 	return list, nil
 }
 
-func migrateSave(ctx context.Context, stmt Stmt, query, name string) error {
-	return stmt.Exec(ctx, "save migration", func(q Executor) {
-		q.SQL(query, name, time.Now().Unix())
-		q.Bind(func(rowsAffected, _ int64) error {
-			if rowsAffected != 1 {
-				return fmt.Errorf("cant save migration [%s]", name)
+func migrateChecksumList(ctx context.Context, stmt Stmt, query string, list map[string]migrationRecord) error {
+	return stmt.Query(ctx, "list migration checksums", func(q Querier) {
+		q.SQL(query)
+		q.Bind(func(bind Scanner) error {
+			var name, checksum string
+			if err := bind.Scan(&name, &checksum); err != nil {
+				return err
+			}
+			if record, ok := list[name]; ok {
+				record.Checksum = checksum
+				list[name] = record
 			}
 			return nil
 		})
 	})
 }
+
+func migrateSave(ctx context.Context, db DB, query, name, checksum string) error {
+	result, err := db.ExecContext(ctx, query, name, checksum, time.Now().Unix())
+	if err != nil {
+		return err
+	}
+	if n, err := result.RowsAffected(); err != nil || n != 1 {
+		return errors.Wrap(fmt.Errorf("cant save migration [%s]", name), err)
+	}
+	return nil
+}
+
+func migrateDelete(ctx context.Context, db DB, query, name string) error {
+	result, err := db.ExecContext(ctx, query, name)
+	if err != nil {
+		return err
+	}
+	if n, err := result.RowsAffected(); err != nil || n != 1 {
+		return errors.Wrap(fmt.Errorf("cant delete migration [%s]", name), err)
+	}
+	return nil
+}
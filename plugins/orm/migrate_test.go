@@ -5,31 +5,176 @@
 
 package orm
 
-import "testing"
-
-func Test_removeSQLComment(t *testing.T) {
-
-	tests := []struct {
-		name string
-		arg  string
-		want string
-	}{
-		{
-			name: "Case1",
-			arg:  "-- SEQUENCE\nCREATE SEQUENCE IF",
-			want: "CREATE SEQUENCE IF",
-		},
-		{
-			name: "Case2",
-			arg:  "\n-- SEQUENCE\n-- SEQUENCE\n-- SEQUENCE\n\nCREATE SEQUENCE IF",
-			want: "CREATE SEQUENCE IF",
-		},
+import (
+	"context"
+	"database/sql"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"go.osspkg.com/casecheck"
+	"go.osspkg.com/errors"
+
+	"go.osspkg.com/goppy/v3/plugins/orm/clients/sqlite"
+	"go.osspkg.com/goppy/v3/plugins/orm/dialect"
+)
+
+func TestUnit_MigrateUpDown(t *testing.T) {
+	ctx := context.Background()
+
+	db := New(ctx)
+	defer db.Close()
+
+	casecheck.NoError(t, db.ApplyConfig(sqlite.Name, &sqlite.ConfigGroup{
+		Pool: []sqlite.Config{{Tags: "master", File: filepath.Join(t.TempDir(), "test.db")}},
+	}))
+
+	files := map[string]string{
+		"0001_users.up.sql":   "CREATE TABLE users (id integer);",
+		"0001_users.down.sql": "DROP TABLE users;",
+		"0002_posts.up.sql":   "CREATE TABLE posts (id integer);\n-- comment\nCREATE TABLE tags (id integer);",
+		"0002_posts.down.sql": "DROP TABLE tags; DROP TABLE posts;",
+		"0003_seed.sql":       "INSERT INTO users (id) VALUES (1);",
 	}
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			if got := removeSQLComment(tt.arg); got != tt.want {
-				t.Errorf("removeSQLComment() = %v, want %v", got, tt.want)
-			}
-		})
+	newMigrate := func() Migrator {
+		return NewMigrate(db, NewVirtualFS([]Migration{
+			{Tags: []string{"master"}, Dialect: sqlite.Name, Data: files},
+		}))
+	}
+	names := func(list []MigrationState) []string {
+		result := make([]string, 0, len(list))
+		for _, s := range list {
+			result = append(result, s.Name+":"+string(s.Status))
+		}
+		return result
+	}
+
+	m := newMigrate()
+
+	plan, err := m.Plan(ctx)
+	casecheck.NoError(t, err)
+	casecheck.Equal(t, []string{
+		"0001_users.up.sql:pending", "0002_posts.up.sql:pending", "0003_seed.sql:pending",
+	}, names(plan))
+	casecheck.False(t, migrateTableCheck(ctx, db.Tag("master"), `SELECT "name" FROM "sqlite_master" WHERE "name"='__migrations__';`))
+
+	casecheck.NoError(t, m.Run(ctx))
+
+	status, err := m.Status(ctx)
+	casecheck.NoError(t, err)
+	casecheck.Equal(t, []string{
+		"0001_users.up.sql:applied", "0002_posts.up.sql:applied", "0003_seed.sql:applied",
+	}, names(status))
+	casecheck.True(t, status[0].Reversible)
+	casecheck.False(t, status[2].Reversible)
+
+	_, err = m.RollbackPlan(ctx, 1)
+	casecheck.Error(t, err)
+	casecheck.True(t, errors.Is(err, ErrMigrationNoDown))
+
+	files["0002_posts.up.sql"] += "\n-- edited"
+	status, err = m.Status(ctx)
+	casecheck.NoError(t, err)
+	casecheck.Equal(t, "0002_posts.up.sql:modified", names(status)[1])
+
+	err = m.Run(ctx)
+	casecheck.Error(t, err)
+	casecheck.True(t, errors.Is(err, ErrMigrationModified))
+
+	delete(files, "0003_seed.sql")
+	files["0002_posts.up.sql"] = strings.TrimSuffix(files["0002_posts.up.sql"], "\n-- edited")
+
+	status, err = m.Status(ctx)
+	casecheck.NoError(t, err)
+	casecheck.Equal(t, []string{
+		"0001_users.up.sql:applied", "0002_posts.up.sql:applied", "0003_seed.sql:missing",
+	}, names(status))
+
+	mig, ok := dialect.GetMigrator(sqlite.Name)
+	casecheck.True(t, ok)
+	casecheck.NoError(t, db.Tag("master").CallContext(ctx, "delete", func(ctx context.Context, db DB) error {
+		return migrateDelete(ctx, db, mig.DeleteQuery(), "0003_seed.sql")
+	}))
+
+	for _, n := range []int{0, -1} {
+		_, err = m.RollbackPlan(ctx, n)
+		casecheck.True(t, errors.Is(err, ErrMigrationSteps))
+		casecheck.True(t, errors.Is(m.Rollback(ctx, n), ErrMigrationSteps))
 	}
+
+	plan, err = m.RollbackPlan(ctx, 5)
+	casecheck.NoError(t, err)
+	casecheck.Equal(t, []string{"0002_posts.up.sql:applied", "0001_users.up.sql:applied"}, names(plan))
+
+	casecheck.NoError(t, m.Rollback(ctx, 1))
+
+	status, err = m.Status(ctx)
+	casecheck.NoError(t, err)
+	casecheck.Equal(t, []string{"0001_users.up.sql:applied", "0002_posts.up.sql:pending"}, names(status))
+
+	casecheck.NoError(t, m.Run(ctx))
+	casecheck.NoError(t, m.Rollback(ctx, 2))
+
+	status, err = m.Status(ctx)
+	casecheck.NoError(t, err)
+	casecheck.Equal(t, []string{"0001_users.up.sql:pending", "0002_posts.up.sql:pending"}, names(status))
+}
+
+func TestUnit_MigrateTransactional(t *testing.T) {
+	ctx := context.Background()
+
+	db := New(ctx)
+	defer db.Close()
+
+	casecheck.NoError(t, db.ApplyConfig(sqlite.Name, &sqlite.ConfigGroup{
+		Pool: []sqlite.Config{{Tags: "master", File: filepath.Join(t.TempDir(), "test.db")}},
+	}))
+
+	files := map[string]string{
+		"0001_users.sql": "CREATE TABLE users (id integer, name text);\n" +
+			"INSERT INTO users (id, name) VALUES (1, 'a;b');\n" +
+			"INSERT INTO unknown (id) VALUES (1);",
+	}
+	m := NewMigrate(db, NewVirtualFS([]Migration{
+		{Tags: []string{"master"}, Dialect: sqlite.Name, Data: files},
+	}))
+
+	casecheck.Error(t, m.Run(ctx))
+	casecheck.False(t, migrateTableCheck(ctx, db.Tag("master"),
+		`SELECT "name" FROM "sqlite_master" WHERE "name"='users';`))
+
+	files["0001_users.sql"] = strings.ReplaceAll(files["0001_users.sql"], "unknown (id) VALUES (1)", "users (id) VALUES (2)")
+	casecheck.NoError(t, m.Run(ctx))
+
+	var name string
+	casecheck.NoError(t, db.Tag("master").Query(ctx, "get", func(q Querier) {
+		q.SQL(`SELECT "name" FROM "users" WHERE "id"=1;`)
+		q.Bind(func(bind Scanner) error {
+			return bind.Scan(&name)
+		})
+	}))
+	casecheck.Equal(t, "a;b", name)
+
+	mig, ok := dialect.GetMigrator(sqlite.Name)
+	casecheck.True(t, ok)
+
+	var raw *sql.DB
+	casecheck.NoError(t, db.Tag("master").CallContext(ctx, "db", func(_ context.Context, db DB) error {
+		raw = db.(*sql.DB)
+		return nil
+	}))
+
+	unlock, err := mig.Lock(ctx, raw)
+	casecheck.NoError(t, err)
+
+	tctx, cancel := context.WithTimeout(ctx, time.Millisecond*300)
+	defer cancel()
+	_, err = mig.Lock(tctx, raw)
+	casecheck.Error(t, err)
+
+	casecheck.NoError(t, unlock())
+	unlock, err = mig.Lock(ctx, raw)
+	casecheck.NoError(t, err)
+	casecheck.NoError(t, unlock())
 }
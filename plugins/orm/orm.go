@@ -7,6 +7,7 @@ package orm
 
 import (
 	"context"
+	"database/sql"
 	"fmt"
 	"time"
 
@@ -24,14 +25,18 @@ var (
 
 type (
 	_orm struct {
-		pool  *syncing.Map[string, Stmt]
-		conns *syncing.Map[string, dialect.Connector]
-		ctx   context.Context
+		pool    *syncing.Map[string, Stmt]
+		conns   *syncing.Map[string, dialect.Connector]
+		groups  *syncing.Map[string, *_group]
+		evicted *syncing.Map[string, struct{}]
+		ctx     context.Context
 	}
 
 	ORM interface {
 		Tag(name string) Stmt
 		ApplyConfig(dialectName dialect.Name, c dialect.ConfigInterface) error
+		ApplyGroupConfig(c GroupConfig) error
+		Stats() map[string]sql.DBStats
 		Close()
 	}
 )
@@ -39,9 +44,11 @@ type (
 // New init database connections
 func New(ctx context.Context) ORM {
 	return &_orm{
-		pool:  syncing.NewMap[string, Stmt](10),
-		conns: syncing.NewMap[string, dialect.Connector](10),
-		ctx:   ctx,
+		pool:    syncing.NewMap[string, Stmt](10),
+		conns:   syncing.NewMap[string, dialect.Connector](10),
+		groups:  syncing.NewMap[string, *_group](2),
+		evicted: syncing.NewMap[string, struct{}](2),
+		ctx:     ctx,
 	}
 }
 
@@ -71,14 +78,32 @@ func (v *_orm) Close() {
 	}
 }
 
-// Tag getting stmt by name
+// Tag getting stmt by name, a group name returns stmt with read/write splitting
 func (v *_orm) Tag(name string) Stmt {
 	if s, ok := v.pool.Get(name); ok {
 		return s
 	}
+	if g, ok := v.groups.Get(name); ok {
+		return g
+	}
 	return newStmt(name, nil, nil, ErrTagNotFound)
 }
 
+// Stats getting the connection pool statistics of every connected tag
+func (v *_orm) Stats() map[string]sql.DBStats {
+	result := make(map[string]sql.DBStats, 10)
+	for _, tag := range v.pool.Keys() {
+		stmt, ok := v.pool.Get(tag)
+		if !ok {
+			continue
+		}
+		if s, ok := stmt.(*_stmt); ok && s.db != nil {
+			result[tag] = s.db.Stats()
+		}
+	}
+	return result
+}
+
 func (v *_orm) ApplyConfig(dialectName dialect.Name, cfg dialect.ConfigInterface) error {
 	c, ok := dialect.GetConnector(dialectName)
 	if !ok {
@@ -126,6 +151,7 @@ func (v *_orm) initConnect(c dialect.Connector, tag string) (Stmt, error) {
 
 func (v *_orm) checkConnects(ctx context.Context) {
 	badConns := make(map[string]struct{}, 10)
+	defer func() { v.checkGroups(ctx, badConns) }()
 
 	for _, tag := range v.pool.Keys() {
 		stmt, ok := v.pool.Get(tag)
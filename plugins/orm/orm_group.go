@@ -0,0 +1,247 @@
+/*
+ *  Copyright (c) 2022-2026 Mikhail Knyazhev <markus621@yandex.com>. All rights reserved.
+ *  Use of this source code is governed by a BSD 3-Clause license that can be found in the LICENSE file.
+ */
+
+package orm
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"go.osspkg.com/logx"
+
+	"go.osspkg.com/goppy/v3/plugins/orm/dialect"
+)
+
+type (
+	// GroupConfigGroup config of tag groups with read/write splitting
+	GroupConfigGroup struct {
+		Groups []GroupConfig `yaml:"db_groups,omitempty"`
+	}
+
+	// GroupConfig tag group: reads go to a healthy replica, writes to the primary.
+	// Replicas behind the primary more than MaxLag are evicted until they catch up,
+	// MaxLag is supported only by the dialects measuring the lag (pgsql).
+	GroupConfig struct {
+		Name     string        `yaml:"name"`
+		Primary  string        `yaml:"primary"`
+		Replicas []string      `yaml:"replicas,omitempty"`
+		MaxLag   time.Duration `yaml:"max_lag,omitempty"`
+	}
+)
+
+func (v *GroupConfigGroup) Validate() error {
+	names := make(map[string]struct{}, len(v.Groups))
+	for i, g := range v.Groups {
+		if len(g.Name) == 0 {
+			return fmt.Errorf("db group: name is required (config=%d)", i)
+		}
+		if _, ok := names[g.Name]; ok {
+			return fmt.Errorf("db group: duplicate name '%s'", g.Name)
+		}
+		names[g.Name] = struct{}{}
+		if len(g.Primary) == 0 {
+			return fmt.Errorf("db group: primary is required (group=%s)", g.Name)
+		}
+		if g.MaxLag < 0 {
+			return fmt.Errorf("db group: max lag must not be negative (group=%s)", g.Name)
+		}
+		for _, tag := range append([]string{g.Primary}, g.Replicas...) {
+			if tag == g.Name {
+				return fmt.Errorf("db group: name must differ from member tags (group=%s)", g.Name)
+			}
+		}
+	}
+	return nil
+}
+
+// ---------------------------------------------------------------------------------------------------------------------
+
+// _group routes queries of a tag group, members are resolved on every call
+// so reconnected statements are picked up
+type _group struct {
+	conf GroupConfig
+	orm  *_orm
+	next atomic.Uint64
+}
+
+func (v *_orm) ApplyGroupConfig(c GroupConfig) error {
+	if len(c.Name) == 0 || len(c.Primary) == 0 {
+		return fmt.Errorf("db group: name and primary are required")
+	}
+	if _, ok := v.pool.Get(c.Name); ok {
+		return fmt.Errorf("db group: name '%s' is used by a connect tag", c.Name)
+	}
+	for _, tag := range append([]string{c.Primary}, c.Replicas...) {
+		if _, ok := v.pool.Get(tag); !ok {
+			return fmt.Errorf("db group: tag '%s' not found (group=%s)", tag, c.Name)
+		}
+	}
+	if c.MaxLag > 0 {
+		for _, tag := range c.Replicas {
+			if conn, ok := v.conns.Get(tag); !ok || !isReplicaLagger(conn) {
+				return fmt.Errorf("db group: max lag is not supported by the dialect of tag '%s' (group=%s)", tag, c.Name)
+			}
+		}
+	}
+
+	v.groups.Set(c.Name, &_group{conf: c, orm: v})
+	logx.Info("Create DB group", "name", c.Name, "primary", c.Primary, "replicas", c.Replicas)
+	return nil
+}
+
+func (v *_group) primary() Stmt {
+	if s, ok := v.orm.pool.Get(v.conf.Primary); ok {
+		return s
+	}
+	return newStmt(v.conf.Primary, nil, nil, ErrTagNotFound)
+}
+
+// replica returns the next healthy replica by round-robin or the primary if none is available
+func (v *_group) replica() Stmt {
+	count := len(v.conf.Replicas)
+	if count == 0 {
+		return v.primary()
+	}
+
+	start := v.next.Add(1)
+	for i := 0; i < count; i++ {
+		tag := v.conf.Replicas[(start+uint64(i))%uint64(count)]
+		if _, bad := v.orm.evicted.Get(evictedKey(v.conf.Name, tag)); bad {
+			continue
+		}
+		if s, ok := v.orm.pool.Get(tag); ok {
+			return s
+		}
+	}
+
+	return v.primary()
+}
+
+func (v *_group) PingContext(ctx context.Context) error {
+	return v.primary().PingContext(ctx)
+}
+
+func (v *_group) CallContext(ctx context.Context, name string, callFunc func(context.Context, DB) error) error {
+	return v.primary().CallContext(ctx, name, callFunc)
+}
+
+func (v *_group) TxContext(ctx context.Context, name string, callFunc func(context.Context, DB) error) error {
+	return v.primary().TxContext(ctx, name, callFunc)
+}
+
+func (v *_group) Exec(ctx context.Context, name string, call func(q Executor)) error {
+	return v.primary().Exec(ctx, name, call)
+}
+
+func (v *_group) Query(ctx context.Context, name string, call func(q Querier)) error {
+	return v.replica().Query(ctx, name, call)
+}
+
+func (v *_group) Tx(ctx context.Context, name string, call func(v Tx)) error {
+	return v.primary().Tx(ctx, name, call)
+}
+
+// Close does nothing, member connects are closed by ORM
+func (v *_group) Close() error {
+	return nil
+}
+
+// evictedKey the replica is evicted per group, the groups can allow different lag of the same tag
+func evictedKey(group, tag string) string {
+	return group + "/" + tag
+}
+
+// checkGroups evicts unavailable replicas and the ones lagging behind more than the group allows
+func (v *_orm) checkGroups(ctx context.Context, badConns map[string]struct{}) {
+	evicted := make(map[string]struct{}, len(badConns))
+	lags := make(map[string]time.Duration, len(badConns)) // the lag of the tag is checked once, -1 if failed
+
+	for _, name := range v.groups.Keys() {
+		g, ok := v.groups.Get(name)
+		if !ok {
+			continue
+		}
+
+		for _, tag := range g.conf.Replicas {
+			key := evictedKey(name, tag)
+			if _, bad := badConns[tag]; bad {
+				evicted[key] = struct{}{}
+				continue
+			}
+			if _, ok = v.pool.Get(tag); !ok {
+				evicted[key] = struct{}{}
+				continue
+			}
+			if g.conf.MaxLag <= 0 {
+				continue
+			}
+
+			lag, checked := lags[tag]
+			if checked && lag < 0 {
+				evicted[key] = struct{}{}
+				continue
+			}
+			if !checked {
+				var err error
+				if lag, err = v.replicaLag(ctx, tag); err != nil {
+					logx.Error("Check DB replica lag", "err", err, "tag", tag)
+					lags[tag] = -1
+					evicted[key] = struct{}{}
+					continue
+				}
+				lags[tag] = lag
+			}
+			if lag > g.conf.MaxLag {
+				logx.Warn("DB replica lag exceeded", "group", name, "tag", tag, "lag", lag, "max", g.conf.MaxLag)
+				evicted[key] = struct{}{}
+			}
+		}
+	}
+
+	for _, key := range v.evicted.Keys() {
+		if _, ok := evicted[key]; !ok {
+			v.evicted.Del(key)
+			logx.Info("Return DB replica", "replica", key)
+		}
+	}
+	for key := range evicted {
+		if _, ok := v.evicted.Get(key); !ok {
+			v.evicted.Set(key, struct{}{})
+			logx.Warn("Evict DB replica", "replica", key)
+		}
+	}
+}
+
+func isReplicaLagger(c dialect.Connector) bool {
+	_, ok := c.(dialect.ReplicaLagger)
+	return ok
+}
+
+func (v *_orm) replicaLag(ctx context.Context, tag string) (time.Duration, error) {
+	c, ok := v.conns.Get(tag)
+	if !ok {
+		return 0, ErrTagNotFound
+	}
+	lc, ok := c.(dialect.ReplicaLagger)
+	if !ok {
+		return 0, nil
+	}
+
+	var lag sql.NullFloat64
+	err := v.Tag(tag).Query(ctx, "replica lag", func(q Querier) {
+		q.SQL(lc.ReplicaLagQuery())
+		q.Bind(func(bind Scanner) error {
+			return bind.Scan(&lag)
+		})
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return time.Duration(lag.Float64 * float64(time.Second)), nil
+}
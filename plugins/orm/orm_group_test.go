@@ -0,0 +1,147 @@
+/*
+ *  Copyright (c) 2022-2026 Mikhail Knyazhev <markus621@yandex.com>. All rights reserved.
+ *  Use of this source code is governed by a BSD 3-Clause license that can be found in the LICENSE file.
+ */
+
+package orm
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"go.osspkg.com/casecheck"
+
+	"go.osspkg.com/goppy/v3/plugins/orm/clients/sqlite"
+)
+
+func TestUnit_GroupReadWriteSplit(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+
+	db := New(ctx)
+	defer db.Close()
+
+	casecheck.NoError(t, db.ApplyConfig(sqlite.Name, &sqlite.ConfigGroup{
+		Pool: []sqlite.Config{
+			{Tags: "master", File: filepath.Join(dir, "master.db")},
+			{Tags: "replica1", File: filepath.Join(dir, "replica1.db")},
+			{Tags: "replica2", File: filepath.Join(dir, "replica2.db")},
+		},
+	}))
+	casecheck.Error(t, db.ApplyGroupConfig(GroupConfig{Name: "master", Primary: "master"}))
+	casecheck.NoError(t, db.ApplyGroupConfig(GroupConfig{
+		Name: "main", Primary: "master", Replicas: []string{"replica1", "replica2"},
+	}))
+
+	for _, tag := range []string{"master", "replica1", "replica2"} {
+		casecheck.NoError(t, db.Tag(tag).Exec(ctx, "create", func(q Executor) {
+			q.SQL(`CREATE TABLE "node" ("name" text);`)
+		}))
+		casecheck.NoError(t, db.Tag(tag).Exec(ctx, "insert", func(q Executor) {
+			q.SQL(`INSERT INTO "node" ("name") VALUES (?);`, tag)
+		}))
+	}
+
+	read := func() string {
+		var name string
+		casecheck.NoError(t, db.Tag("main").Query(ctx, "select", func(q Querier) {
+			q.SQL(`SELECT "name" FROM "node" LIMIT 1;`)
+			q.Bind(func(bind Scanner) error {
+				return bind.Scan(&name)
+			})
+		}))
+		return name
+	}
+
+	reads := map[string]int{}
+	for i := 0; i < 10; i++ {
+		reads[read()]++
+	}
+	casecheck.Equal(t, map[string]int{"replica1": 5, "replica2": 5}, reads)
+
+	casecheck.NoError(t, db.Tag("main").Exec(ctx, "update", func(q Executor) {
+		q.SQL(`UPDATE "node" SET "name"='written';`)
+	}))
+	var name string
+	casecheck.NoError(t, db.Tag("master").Query(ctx, "select", func(q Querier) {
+		q.SQL(`SELECT "name" FROM "node";`)
+		q.Bind(func(bind Scanner) error {
+			return bind.Scan(&name)
+		})
+	}))
+	casecheck.Equal(t, "written", name)
+
+	o := db.(*_orm)
+
+	o.checkGroups(ctx, map[string]struct{}{"replica1": {}})
+	for i := 0; i < 4; i++ {
+		casecheck.Equal(t, "replica2", read())
+	}
+
+	o.checkGroups(ctx, map[string]struct{}{"replica1": {}, "replica2": {}})
+	casecheck.Equal(t, "written", read())
+
+	o.checkConnects(ctx)
+	reads = map[string]int{}
+	for i := 0; i < 4; i++ {
+		reads[read()]++
+	}
+	casecheck.Equal(t, map[string]int{"replica1": 2, "replica2": 2}, reads)
+}
+
+func TestUnit_GroupEvictedPerGroup(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+
+	db := New(ctx)
+	defer db.Close()
+
+	casecheck.NoError(t, db.ApplyConfig(sqlite.Name, &sqlite.ConfigGroup{
+		Pool: []sqlite.Config{
+			{Tags: "master", File: filepath.Join(dir, "master.db")},
+			{Tags: "replica1", File: filepath.Join(dir, "replica1.db")},
+			{Tags: "replica2", File: filepath.Join(dir, "replica2.db")},
+		},
+	}))
+	casecheck.Error(t, db.ApplyGroupConfig(GroupConfig{Name: "unknown", Primary: "master", Replicas: []string{"replica3"}}))
+	casecheck.Error(t, db.ApplyGroupConfig(GroupConfig{Name: "unknown", Primary: "master0"}))
+	casecheck.Error(t, db.ApplyGroupConfig(GroupConfig{
+		Name: "lag", Primary: "master", Replicas: []string{"replica1"}, MaxLag: time.Second,
+	}))
+	casecheck.NoError(t, db.ApplyGroupConfig(GroupConfig{Name: "a", Primary: "master", Replicas: []string{"replica1"}}))
+	casecheck.NoError(t, db.ApplyGroupConfig(GroupConfig{Name: "b", Primary: "master", Replicas: []string{"replica1"}}))
+
+	for _, tag := range []string{"master", "replica1"} {
+		casecheck.NoError(t, db.Tag(tag).Exec(ctx, "create", func(q Executor) {
+			q.SQL(`CREATE TABLE "node" ("name" text);`)
+		}))
+		casecheck.NoError(t, db.Tag(tag).Exec(ctx, "insert", func(q Executor) {
+			q.SQL(`INSERT INTO "node" ("name") VALUES (?);`, tag)
+		}))
+	}
+
+	read := func(group string) string {
+		var name string
+		casecheck.NoError(t, db.Tag(group).Query(ctx, "select", func(q Querier) {
+			q.SQL(`SELECT "name" FROM "node" LIMIT 1;`)
+			q.Bind(func(bind Scanner) error {
+				return bind.Scan(&name)
+			})
+		}))
+		return name
+	}
+
+	o := db.(*_orm)
+	o.evicted.Set(evictedKey("a", "replica1"), struct{}{})
+	casecheck.Equal(t, "master", read("a"))
+	casecheck.Equal(t, "replica1", read("b"))
+
+	o.checkGroups(ctx, map[string]struct{}{})
+	casecheck.Equal(t, "replica1", read("a"))
+
+	o.checkGroups(ctx, map[string]struct{}{"replica1": {}})
+	casecheck.Equal(t, "master", read("a"))
+	casecheck.Equal(t, "master", read("b"))
+}
@@ -0,0 +1,34 @@
+/*
+ *  Copyright (c) 2022-2026 Mikhail Knyazhev <markus621@yandex.com>. All rights reserved.
+ *  Use of this source code is governed by a BSD 3-Clause license that can be found in the LICENSE file.
+ */
+
+package orm
+
+import (
+	"context"
+
+	"go.osspkg.com/goppy/v3/plugins/health"
+)
+
+var _ health.Checker = (*_orm)(nil)
+
+// HealthChecks pings every connected tag, a failed connect makes the application not ready
+func (v *_orm) HealthChecks() []health.Check {
+	tags := v.pool.Keys()
+	result := make([]health.Check, 0, len(tags))
+	for _, tag := range tags {
+		result = append(result, health.Check{
+			Name: "orm:" + tag,
+			Kind: health.Readiness,
+			Func: func(ctx context.Context) error {
+				stmt, ok := v.pool.Get(tag)
+				if !ok {
+					return ErrTagNotFound
+				}
+				return stmt.PingContext(ctx)
+			},
+		})
+	}
+	return result
+}
@@ -12,6 +12,7 @@ import (
 	"go.osspkg.com/errors"
 
 	"go.osspkg.com/goppy/v3/plugins/orm/metric"
+	"go.osspkg.com/goppy/v3/plugins/trace"
 )
 
 // PingContext database ping
@@ -35,6 +36,12 @@ func (v *_stmt) CallContext(ctx context.Context, name string, callFunc func(cont
 		return
 	}
 
+	ctx, span := v.startSpan(ctx, name)
+	defer func() {
+		span.SetError(err)
+		span.End()
+	}()
+
 	metric.ExecTime(v.tag, name, func() {
 		if err = callFunc(ctx, v.db); err != nil {
 			err = fmt.Errorf("failed calling %s: %w", name, err)
@@ -45,11 +52,18 @@ func (v *_stmt) CallContext(ctx context.Context, name string, callFunc func(cont
 }
 
 // TxContext the basic execution of a query in a transaction
-func (v *_stmt) TxContext(ctx context.Context, name string, callFunc func(context.Context, DB) error) error {
-	if err := v.err.Get(); err != nil {
+func (v *_stmt) TxContext(ctx context.Context, name string, callFunc func(context.Context, DB) error) (err error) {
+	if err = v.err.Get(); err != nil {
 		return err
 	}
 
+	ctx, span := v.startSpan(ctx, name)
+	span.SetAttr("db.transaction", true)
+	defer func() {
+		span.SetError(err)
+		span.End()
+	}()
+
 	dbx, err := v.db.BeginTx(ctx, nil)
 	if err != nil {
 		return fmt.Errorf("failed beginning transaction: %w", err)
@@ -74,3 +88,11 @@ func (v *_stmt) TxContext(ctx context.Context, name string, callFunc func(contex
 
 	return nil
 }
+
+// startSpan starts the client span named by the query name
+func (v *_stmt) startSpan(ctx context.Context, name string) (context.Context, trace.Span) {
+	ctx, span := trace.Start(ctx, name, trace.KindClient)
+	span.SetAttr("db.system", string(v.dialect.Dialect()))
+	span.SetAttr("db.tag", v.tag)
+	return ctx, span
+}
@@ -0,0 +1,69 @@
+/*
+ *  Copyright (c) 2022-2026 Mikhail Knyazhev <markus621@yandex.com>. All rights reserved.
+ *  Use of this source code is governed by a BSD 3-Clause license that can be found in the LICENSE file.
+ */
+
+package orm
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"go.osspkg.com/casecheck"
+
+	"go.osspkg.com/goppy/v3/plugins/orm/clients/sqlite"
+	"go.osspkg.com/goppy/v3/plugins/trace"
+)
+
+func TestUnit_StmtSpans(t *testing.T) {
+	exp := trace.NewMemoryExporter()
+	trace.SetExporter(exp)
+	defer trace.SetExporter(nil)
+
+	db := New(context.Background())
+	defer db.Close()
+
+	dir := t.TempDir()
+	casecheck.NoError(t, db.ApplyConfig(sqlite.Name, &sqlite.ConfigGroup{
+		Pool: []sqlite.Config{
+			{Tags: "master", File: filepath.Join(dir, "master.db")},
+			{Tags: "replica1", File: filepath.Join(dir, "replica1.db")},
+			{Tags: "replica2", File: filepath.Join(dir, "replica2.db")},
+		},
+	}))
+
+	ctx, root := trace.Start(context.Background(), "request", trace.KindServer)
+	casecheck.NoError(t, db.Tag("master").Exec(ctx, "create", func(q Executor) {
+		q.SQL(`CREATE TABLE "node" ("name" text);`)
+	}))
+	casecheck.NoError(t, db.Tag("master").Tx(ctx, "insert", func(tx Tx) {
+		tx.Exec(func(q Executor) {
+			q.SQL(`INSERT INTO "node" ("name") VALUES (?);`, "a")
+		})
+	}))
+	casecheck.Error(t, db.Tag("master").Query(ctx, "select", func(q Querier) {
+		q.SQL(`SELECT "name" FROM "unknown";`)
+		q.Bind(func(bind Scanner) error { return nil })
+	}))
+	root.End()
+
+	spans := exp.Spans()
+	casecheck.Equal(t, 4, len(spans))
+
+	names := make([]string, 0, len(spans))
+	for _, s := range spans[:3] {
+		names = append(names, s.Name)
+		casecheck.Equal(t, trace.KindClient, s.Kind)
+		casecheck.Equal(t, root.Context().TraceID, s.TraceID)
+		casecheck.Equal(t, root.Context().SpanID, s.ParentSpanID)
+		casecheck.Equal(t, string(sqlite.Name), s.Attrs["db.system"])
+		casecheck.Equal(t, "master", s.Attrs["db.tag"])
+	}
+	casecheck.Equal(t, []string{"create", "insert", "select"}, names)
+
+	casecheck.Equal(t, nil, spans[0].Attrs["db.transaction"])
+	casecheck.Equal(t, true, spans[1].Attrs["db.transaction"])
+	casecheck.Equal(t, "", spans[1].Error)
+	casecheck.NotEqual(t, "", spans[2].Error)
+}
@@ -6,10 +6,13 @@
 package orm
 
 import (
+	"context"
+
 	"go.osspkg.com/logx"
 
 	"go.osspkg.com/goppy/v3/pkg/xc"
 
+	"go.osspkg.com/goppy/v3/pkg/console"
 	"go.osspkg.com/goppy/v3/plugin"
 )
 
@@ -39,3 +42,91 @@ func WithMigration(ms ...Migration) plugin.Kind {
 		},
 	}
 }
+
+// MigrationCommand console command `migrate` with `up`, `down` and `status` subcommands.
+// Use the same migrations as passed to WithMigration:
+//
+//	app.Plugins(orm.WithORM(sqlite.Name), orm.WithMigration())
+//	app.Command(orm.MigrationCommand())
+func MigrationCommand(ms ...Migration) func(context.Context, plugin.DIResolver, console.CommandSetter) {
+	return func(ctx context.Context, r plugin.DIResolver, setter console.CommandSetter) {
+		resolve := func(call func(m Migrator)) {
+			var err error
+			if len(ms) > 0 {
+				err = r.Resolve(func(o ORM) {
+					call(NewMigrate(o, NewVirtualFS(ms)))
+				})
+			} else {
+				err = r.Resolve(func(c *ConfigGroup, o ORM) {
+					call(NewMigrate(o, NewOperationSystemFS(c.List)))
+				})
+			}
+			console.FatalIfErr(err, "resolve migrator")
+		}
+
+		setter.Setup("migrate", "database migrations")
+
+		setter.AddCommand(console.NewCommand(func(setter console.CommandSetter) {
+			setter.Setup("up", "apply pending migrations")
+			setter.Flag(func(f console.FlagsSetter) {
+				f.Bool("dry-run", "show pending migrations without applying")
+			})
+			setter.ExecFunc(func(dryRun bool) {
+				resolve(func(m Migrator) {
+					if dryRun {
+						list, err := m.Plan(ctx)
+						console.FatalIfErr(err, "plan migrations")
+						printMigrationStates(list)
+						return
+					}
+					console.FatalIfErr(m.Run(ctx), "apply migrations")
+				})
+			})
+		}))
+
+		setter.AddCommand(console.NewCommand(func(setter console.CommandSetter) {
+			setter.Setup("down", "rollback applied migrations")
+			setter.Flag(func(f console.FlagsSetter) {
+				f.IntVar("steps", 1, "count of migrations to rollback")
+				f.Bool("dry-run", "show migrations to rollback without reverting")
+			})
+			setter.ExecFunc(func(steps int64, dryRun bool) {
+				console.FatalIfTrue(steps < 1, "steps must be greater than 0")
+				resolve(func(m Migrator) {
+					if dryRun {
+						list, err := m.RollbackPlan(ctx, int(steps))
+						console.FatalIfErr(err, "plan rollback")
+						printMigrationStates(list)
+						return
+					}
+					console.FatalIfErr(m.Rollback(ctx, int(steps)), "rollback migrations")
+				})
+			})
+		}))
+
+		setter.AddCommand(console.NewCommand(func(setter console.CommandSetter) {
+			setter.Setup("status", "show applied, pending and modified migrations")
+			setter.ExecFunc(func() {
+				resolve(func(m Migrator) {
+					list, err := m.Status(ctx)
+					console.FatalIfErr(err, "migrations status")
+					printMigrationStates(list)
+				})
+			})
+		}))
+	}
+}
+
+func printMigrationStates(list []MigrationState) {
+	if len(list) == 0 {
+		console.Infof("no migrations")
+		return
+	}
+	for _, s := range list {
+		applied := "-"
+		if !s.AppliedAt.IsZero() && s.Status != MigrationPending {
+			applied = s.AppliedAt.Format("2006-01-02 15:04:05")
+		}
+		console.Rawf("%-8s %-10s %-8s %-19s %s", s.Dialect, s.Tag, s.Status, applied, s.Name)
+	}
+}
@@ -26,7 +26,9 @@ func WithORM(dialectNames ...dialect.Name) plugin.Kind {
 		kind plugin.Kind
 	)
 
-	cfgs := make([]dialect.ConfigInterface, 0, len(dialectNames))
+	groups := &GroupConfigGroup{}
+	cfgs := make([]any, 0, len(dialectNames)+1)
+	cfgs = append(cfgs, groups)
 	links := make(map[dialect.Name]dialect.ConfigInterface)
 
 	if len(dialectNames) == 0 {
@@ -74,6 +76,12 @@ func WithORM(dialectNames ...dialect.Name) plugin.Kind {
 
 		}
 
+		for _, g := range groups.Groups {
+			if err = obj.ApplyGroupConfig(g); err != nil {
+				return nil, fmt.Errorf("orm: apply group '%s': %w", g.Name, err)
+			}
+		}
+
 		return obj, nil
 	}
 
@@ -55,6 +55,11 @@ func (p *goPlugin) Stop() error {
 	return p.funcStop()
 }
 
+// Alive the plugin is loaded into the application process
+func (p *goPlugin) Alive() error {
+	return nil
+}
+
 func (p *goPlugin) Call(ctx context.Context, method string, params, result any) error {
 	defer func() {
 		if err := recover(); err != nil {
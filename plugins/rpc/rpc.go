@@ -8,15 +8,21 @@ package rpc
 import (
 	"context"
 	"fmt"
+	"sync"
 
 	"go.osspkg.com/logx"
+
+	"go.osspkg.com/goppy/v3/plugins/health"
 )
 
 type RPC struct {
 	conf    []Config
 	plugins map[string]rpcPlugin
+	mux     sync.RWMutex
 }
 
+var _ health.Checker = (*RPC)(nil)
+
 func New(conf ...Config) *RPC {
 	return &RPC{
 		conf:    conf,
@@ -25,6 +31,9 @@ func New(conf ...Config) *RPC {
 }
 
 func (v *RPC) Up(ctx context.Context) error {
+	v.mux.Lock()
+	defer v.mux.Unlock()
+
 	for _, c := range v.conf {
 		if _, ok := v.plugins[c.Name]; ok {
 			return fmt.Errorf("plugin %s already exists", c.Name)
@@ -58,6 +67,9 @@ func (v *RPC) Up(ctx context.Context) error {
 }
 
 func (v *RPC) Down() error {
+	v.mux.RLock()
+	defer v.mux.RUnlock()
+
 	for name, p := range v.plugins {
 		if err := p.Stop(); err != nil {
 			logx.Error("failed to stop rpc plugin", "err", err, "name", name)
@@ -68,8 +80,35 @@ func (v *RPC) Down() error {
 }
 
 func (v *RPC) Call(ctx context.Context, name string, method string, params, result any) error {
-	if p, ok := v.plugins[name]; ok {
+	if p, ok := v.plugin(name); ok {
 		return p.Call(ctx, method, params, result)
 	}
 	return fmt.Errorf("plugin %s not found", name)
 }
+
+// HealthChecks the plugin is started and its process is alive
+func (v *RPC) HealthChecks() []health.Check {
+	result := make([]health.Check, 0, len(v.conf))
+	for _, c := range v.conf {
+		result = append(result, health.Check{
+			Name: "rpc:" + c.Name,
+			Kind: health.Readiness,
+			Func: func(_ context.Context) error {
+				p, ok := v.plugin(c.Name)
+				if !ok {
+					return fmt.Errorf("plugin %s not started", c.Name)
+				}
+				return p.Alive()
+			},
+		})
+	}
+	return result
+}
+
+func (v *RPC) plugin(name string) (rpcPlugin, bool) {
+	v.mux.RLock()
+	defer v.mux.RUnlock()
+
+	p, ok := v.plugins[name]
+	return p, ok
+}
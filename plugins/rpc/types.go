@@ -10,5 +10,6 @@ import "context"
 type rpcPlugin interface {
 	Start(ctx context.Context, opts map[string]string) error
 	Stop() error
+	Alive() error
 	Call(ctx context.Context, method string, params, result any) (err error)
 }
@@ -10,6 +10,7 @@ import (
 	"fmt"
 	"os"
 	"strings"
+	"sync/atomic"
 
 	"github.com/google/uuid"
 	"go.osspkg.com/do"
@@ -32,9 +33,10 @@ type unixPlugin struct {
 
 	conf Config
 
-	ctx    context.Context
-	cancel context.CancelFunc
-	wg     syncing.Group
+	ctx     context.Context
+	cancel  context.CancelFunc
+	wg      syncing.Group
+	running atomic.Bool
 }
 
 //nolint:unparam
@@ -78,7 +80,10 @@ func (p *unixPlugin) Start(gx context.Context, opts map[string]string) error {
 				return
 			default:
 				os.Remove(p.socketPath) //nolint:errcheck
-				if err := p.serv.CallContext(ctx, lw, fullCmd); err != nil {
+				p.running.Store(true)
+				err := p.serv.CallContext(ctx, lw, fullCmd)
+				p.running.Store(false)
+				if err != nil {
 					logx.Error(
 						"Unix Plugin",
 						"err", err,
@@ -99,6 +104,14 @@ func (p *unixPlugin) Stop() error {
 	return nil
 }
 
+// Alive the child process is running, it is restarted in the background after the exit
+func (p *unixPlugin) Alive() error {
+	if !p.running.Load() {
+		return fmt.Errorf("process is not running")
+	}
+	return nil
+}
+
 func (p *unixPlugin) Call(ctx context.Context, method string, params, result any) error {
 	in := jsonrpc.ModelAdapter[any]{Data: params}
 	out := jsonrpc.ModelAdapter[any]{Data: result}
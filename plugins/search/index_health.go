@@ -0,0 +1,35 @@
+/*
+ *  Copyright (c) 2022-2026 Mikhail Knyazhev <markus621@yandex.com>. All rights reserved.
+ *  Use of this source code is governed by a BSD 3-Clause license that can be found in the LICENSE file.
+ */
+
+package search
+
+import (
+	"context"
+
+	"go.osspkg.com/errors"
+
+	"go.osspkg.com/goppy/v3/plugins/health"
+)
+
+var _ health.Checker = (*service)(nil)
+
+// HealthChecks every created index is open and readable
+func (v *service) HealthChecks() []health.Check {
+	return []health.Check{
+		{
+			Name: "search",
+			Kind: health.Readiness,
+			Func: func(_ context.Context) error {
+				var err error
+				for name, index := range v.list.Yield() {
+					if _, e := index.DocCount(); e != nil {
+						err = errors.Wrap(err, errors.Wrapf(e, "index '%s'", name))
+					}
+				}
+				return err
+			},
+		},
+	}
+}
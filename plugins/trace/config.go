@@ -0,0 +1,53 @@
+/*
+ *  Copyright (c) 2022-2026 Mikhail Knyazhev <markus621@yandex.com>. All rights reserved.
+ *  Use of this source code is governed by a BSD 3-Clause license that can be found in the LICENSE file.
+ */
+
+package trace
+
+import (
+	"fmt"
+	"net/url"
+	"time"
+)
+
+type (
+	ConfigGroup struct {
+		Trace Config `yaml:"trace"`
+	}
+	// Config of the OTLP/HTTP exporter
+	Config struct {
+		// Endpoint full url of the collector, e.g. http://127.0.0.1:4318/v1/traces
+		Endpoint  string            `yaml:"endpoint"`
+		Headers   map[string]string `yaml:"headers,omitempty"`
+		BatchSize int               `yaml:"batch_size,omitempty"`
+		Interval  time.Duration     `yaml:"interval,omitempty"`
+		Timeout   time.Duration     `yaml:"timeout,omitempty"`
+	}
+)
+
+func (v *ConfigGroup) Default() {
+	v.Trace.Endpoint = "http://127.0.0.1:4318/v1/traces"
+	v.Trace.BatchSize = 512
+	v.Trace.Interval = 5 * time.Second
+	v.Trace.Timeout = 10 * time.Second
+}
+
+func (v *ConfigGroup) Validate() error {
+	if len(v.Trace.Endpoint) == 0 {
+		return fmt.Errorf("trace: missing endpoint")
+	}
+	if _, err := url.ParseRequestURI(v.Trace.Endpoint); err != nil {
+		return fmt.Errorf("trace: invalid endpoint: %w", err)
+	}
+	if v.Trace.BatchSize <= 0 {
+		return fmt.Errorf("trace: batch size must be positive")
+	}
+	if v.Trace.Interval <= 0 {
+		return fmt.Errorf("trace: interval must be positive")
+	}
+	if v.Trace.Timeout <= 0 {
+		return fmt.Errorf("trace: timeout must be positive")
+	}
+	return nil
+}
@@ -0,0 +1,89 @@
+/*
+ *  Copyright (c) 2022-2026 Mikhail Knyazhev <markus621@yandex.com>. All rights reserved.
+ *  Use of this source code is governed by a BSD 3-Clause license that can be found in the LICENSE file.
+ */
+
+package trace
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+type (
+	// SpanData finished span passed to the exporter
+	SpanData struct {
+		Name         string
+		Kind         Kind
+		TraceID      TraceID
+		SpanID       SpanID
+		ParentSpanID SpanID
+		Start        time.Time
+		End          time.Time
+		Attrs        map[string]any
+		Error        string
+		Links        []SpanContext
+	}
+
+	// Exporter receives the finished spans, Export is called synchronously in End and must not block
+	Exporter interface {
+		Export(span SpanData)
+	}
+)
+
+type exporterHolder struct {
+	exp Exporter
+}
+
+var store atomic.Pointer[exporterHolder]
+
+// SetExporter sets the exporter of all spans, nil disables recording
+func SetExporter(exp Exporter) {
+	store.Store(&exporterHolder{exp: exp})
+}
+
+func getExporter() Exporter {
+	if h := store.Load(); h != nil {
+		return h.exp
+	}
+	return nil
+}
+
+/**********************************************************************************************************************/
+
+// MemoryExporter keeps the finished spans in memory, used in tests
+type MemoryExporter struct {
+	spans []SpanData
+	mux   sync.Mutex
+}
+
+func NewMemoryExporter() *MemoryExporter {
+	return &MemoryExporter{
+		spans: make([]SpanData, 0, 10),
+	}
+}
+
+func (v *MemoryExporter) Export(span SpanData) {
+	v.mux.Lock()
+	defer v.mux.Unlock()
+
+	v.spans = append(v.spans, span)
+}
+
+// Spans getting a copy of the finished spans in the order of completion
+func (v *MemoryExporter) Spans() []SpanData {
+	v.mux.Lock()
+	defer v.mux.Unlock()
+
+	result := make([]SpanData, len(v.spans))
+	copy(result, v.spans)
+	return result
+}
+
+func (v *MemoryExporter) Reset() {
+	v.mux.Lock()
+	defer v.mux.Unlock()
+
+	v.spans = v.spans[:0]
+}
@@ -0,0 +1,264 @@
+/*
+ *  Copyright (c) 2022-2026 Mikhail Knyazhev <markus621@yandex.com>. All rights reserved.
+ *  Use of this source code is governed by a BSD 3-Clause license that can be found in the LICENSE file.
+ */
+
+package trace
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"go.osspkg.com/logx"
+	"go.osspkg.com/syncing"
+
+	"go.osspkg.com/goppy/v3/pkg/xc"
+)
+
+// OTLPExporter sends spans in batches to the OpenTelemetry collector by OTLP/HTTP with JSON encoding.
+// The native http.Client is used so the exporter does not trace itself.
+type OTLPExporter struct {
+	conf    Config
+	service string
+	cli     *http.Client
+	queue   chan SpanData
+	cancel  context.CancelFunc
+	wg      syncing.Group
+}
+
+func NewOTLPExporter(ctx context.Context, service string, conf Config) *OTLPExporter {
+	ctx, cancel := context.WithCancel(ctx)
+	return &OTLPExporter{
+		conf:    conf,
+		service: service,
+		cli:     &http.Client{Timeout: conf.Timeout},
+		queue:   make(chan SpanData, conf.BatchSize*4),
+		cancel:  cancel,
+		wg:      syncing.NewGroup(ctx),
+	}
+}
+
+func (v *OTLPExporter) Up(_ xc.Context) error {
+	v.wg.Background("otlp exporter", v.loop)
+	SetExporter(v)
+
+	logx.Info("Trace OTLP Exporter", "do", "start", "endpoint", v.conf.Endpoint)
+	return nil
+}
+
+func (v *OTLPExporter) Down() error {
+	SetExporter(nil)
+	v.cancel()
+	v.wg.Wait()
+
+	logx.Info("Trace OTLP Exporter", "do", "stop", "endpoint", v.conf.Endpoint)
+	return nil
+}
+
+// Export queues the span, the span is dropped if the queue is full
+func (v *OTLPExporter) Export(span SpanData) {
+	select {
+	case v.queue <- span:
+	default:
+	}
+}
+
+func (v *OTLPExporter) loop(ctx context.Context) {
+	tick := time.NewTicker(v.conf.Interval)
+	defer tick.Stop()
+
+	batch := make([]SpanData, 0, v.conf.BatchSize)
+	flush := func() {
+		if len(batch) == 0 {
+			return
+		}
+		if err := v.send(batch); err != nil {
+			logx.Error("Trace OTLP Exporter", "do", "send spans", "count", len(batch), "err", err)
+		}
+		batch = batch[:0]
+	}
+
+	for {
+		select {
+		case span := <-v.queue:
+			batch = append(batch, span)
+			if len(batch) >= v.conf.BatchSize {
+				flush()
+			}
+		case <-tick.C:
+			flush()
+		case <-ctx.Done():
+			for {
+				select {
+				case span := <-v.queue:
+					batch = append(batch, span)
+				default:
+					flush()
+					return
+				}
+			}
+		}
+	}
+}
+
+func (v *OTLPExporter) send(spans []SpanData) error {
+	b, err := json.Marshal(encodeOTLP(v.service, spans))
+	if err != nil {
+		return fmt.Errorf("encode: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodPost, v.conf.Endpoint, bytes.NewReader(b))
+	if err != nil {
+		return fmt.Errorf("create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for key, val := range v.conf.Headers {
+		req.Header.Set(key, val)
+	}
+
+	resp, err := v.cli.Do(req)
+	if err != nil {
+		return fmt.Errorf("send request: %w", err)
+	}
+	defer resp.Body.Close() //nolint:errcheck
+
+	if _, err = io.Copy(io.Discard, resp.Body); err != nil {
+		return fmt.Errorf("read response: %w", err)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("bad status code: %d", resp.StatusCode)
+	}
+	return nil
+}
+
+/**********************************************************************************************************************/
+
+type (
+	otlpRequest struct {
+		ResourceSpans []otlpResourceSpans `json:"resourceSpans"`
+	}
+	otlpResourceSpans struct {
+		Resource   otlpResource     `json:"resource"`
+		ScopeSpans []otlpScopeSpans `json:"scopeSpans"`
+	}
+	otlpResource struct {
+		Attributes []otlpKeyValue `json:"attributes"`
+	}
+	otlpScopeSpans struct {
+		Scope otlpScope  `json:"scope"`
+		Spans []otlpSpan `json:"spans"`
+	}
+	otlpScope struct {
+		Name string `json:"name"`
+	}
+	otlpSpan struct {
+		TraceID           string         `json:"traceId"`
+		SpanID            string         `json:"spanId"`
+		ParentSpanID      string         `json:"parentSpanId,omitempty"`
+		Name              string         `json:"name"`
+		Kind              int            `json:"kind"`
+		StartTimeUnixNano string         `json:"startTimeUnixNano"`
+		EndTimeUnixNano   string         `json:"endTimeUnixNano"`
+		Attributes        []otlpKeyValue `json:"attributes,omitempty"`
+		Links             []otlpLink     `json:"links,omitempty"`
+		Status            *otlpStatus    `json:"status,omitempty"`
+	}
+	otlpLink struct {
+		TraceID string `json:"traceId"`
+		SpanID  string `json:"spanId"`
+	}
+	otlpStatus struct {
+		Code    int    `json:"code"`
+		Message string `json:"message,omitempty"`
+	}
+	otlpKeyValue struct {
+		Key   string       `json:"key"`
+		Value otlpAnyValue `json:"value"`
+	}
+	otlpAnyValue struct {
+		StringValue *string  `json:"stringValue,omitempty"`
+		BoolValue   *bool    `json:"boolValue,omitempty"`
+		IntValue    *string  `json:"intValue,omitempty"`
+		DoubleValue *float64 `json:"doubleValue,omitempty"`
+	}
+)
+
+const (
+	otlpScopeName   = "go.osspkg.com/goppy/v3/plugins/trace"
+	otlpStatusError = 2
+)
+
+func encodeOTLP(service string, spans []SpanData) otlpRequest {
+	list := make([]otlpSpan, 0, len(spans))
+	for _, s := range spans {
+		item := otlpSpan{
+			TraceID:           s.TraceID.String(),
+			SpanID:            s.SpanID.String(),
+			Name:              s.Name,
+			Kind:              int(s.Kind),
+			StartTimeUnixNano: strconv.FormatInt(s.Start.UnixNano(), 10),
+			EndTimeUnixNano:   strconv.FormatInt(s.End.UnixNano(), 10),
+		}
+		if s.ParentSpanID.IsValid() {
+			item.ParentSpanID = s.ParentSpanID.String()
+		}
+		for key, val := range s.Attrs {
+			item.Attributes = append(item.Attributes, otlpKeyValue{Key: key, Value: otlpValue(val)})
+		}
+		for _, l := range s.Links {
+			item.Links = append(item.Links, otlpLink{TraceID: l.TraceID.String(), SpanID: l.SpanID.String()})
+		}
+		if len(s.Error) > 0 {
+			item.Status = &otlpStatus{Code: otlpStatusError, Message: s.Error}
+		}
+		list = append(list, item)
+	}
+
+	return otlpRequest{
+		ResourceSpans: []otlpResourceSpans{{
+			Resource: otlpResource{
+				Attributes: []otlpKeyValue{{Key: "service.name", Value: otlpValue(service)}},
+			},
+			ScopeSpans: []otlpScopeSpans{{
+				Scope: otlpScope{Name: otlpScopeName},
+				Spans: list,
+			}},
+		}},
+	}
+}
+
+func otlpValue(val any) otlpAnyValue {
+	switch v := val.(type) {
+	case string:
+		return otlpAnyValue{StringValue: &v}
+	case bool:
+		return otlpAnyValue{BoolValue: &v}
+	case int:
+		return otlpInt(int64(v))
+	case int32:
+		return otlpInt(int64(v))
+	case int64:
+		return otlpInt(v)
+	case uint16:
+		return otlpInt(int64(v))
+	case uint32:
+		return otlpInt(int64(v))
+	case float64:
+		return otlpAnyValue{DoubleValue: &v}
+	default:
+		s := fmt.Sprintf("%v", v)
+		return otlpAnyValue{StringValue: &s}
+	}
+}
+
+// otlpInt int64 is encoded as a decimal string in the JSON mapping of OTLP
+func otlpInt(v int64) otlpAnyValue {
+	s := strconv.FormatInt(v, 10)
+	return otlpAnyValue{IntValue: &s}
+}
@@ -0,0 +1,83 @@
+/*
+ *  Copyright (c) 2022-2026 Mikhail Knyazhev <markus621@yandex.com>. All rights reserved.
+ *  Use of this source code is governed by a BSD 3-Clause license that can be found in the LICENSE file.
+ */
+
+package trace
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"go.osspkg.com/casecheck"
+)
+
+func TestUnit_OTLPExporter(t *testing.T) {
+	var (
+		mux  sync.Mutex
+		reqs []otlpRequest
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		casecheck.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		casecheck.Equal(t, "secret", r.Header.Get("Authorization"))
+
+		var req otlpRequest
+		casecheck.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+
+		mux.Lock()
+		reqs = append(reqs, req)
+		mux.Unlock()
+	}))
+	defer srv.Close()
+
+	exp := NewOTLPExporter(context.Background(), "app", Config{
+		Endpoint:  srv.URL + "/v1/traces",
+		Headers:   map[string]string{"Authorization": "secret"},
+		BatchSize: 10,
+		Interval:  time.Hour,
+		Timeout:   time.Second,
+	})
+	casecheck.NoError(t, exp.Up(nil))
+
+	ctx, root := Start(context.Background(), "root", KindServer)
+	_, child := Start(ctx, "child", KindClient)
+	child.SetAttr("db.tag", "master")
+	child.SetAttr("http.response.status_code", 200)
+	child.End()
+	_, linked := StartLinked(ctx, "linked", KindConsumer, root.Context())
+	linked.End()
+	root.End()
+
+	casecheck.NoError(t, exp.Down())
+
+	mux.Lock()
+	defer mux.Unlock()
+
+	casecheck.Equal(t, 1, len(reqs))
+	rs := reqs[0].ResourceSpans[0]
+	casecheck.Equal(t, "app", *rs.Resource.Attributes[0].Value.StringValue)
+
+	spans := rs.ScopeSpans[0].Spans
+	casecheck.Equal(t, 3, len(spans))
+	casecheck.Equal(t, "child", spans[0].Name)
+	casecheck.Equal(t, int(KindClient), spans[0].Kind)
+	casecheck.Equal(t, root.Context().SpanID.String(), spans[0].ParentSpanID)
+	casecheck.Equal(t, root.Context().TraceID.String(), spans[0].TraceID)
+	casecheck.Equal(t, "", spans[1].ParentSpanID)
+	casecheck.Equal(t, []otlpLink{{
+		TraceID: root.Context().TraceID.String(), SpanID: root.Context().SpanID.String(),
+	}}, spans[1].Links)
+	casecheck.Equal(t, "", spans[2].ParentSpanID)
+
+	attrs := map[string]otlpAnyValue{}
+	for _, kv := range spans[0].Attributes {
+		attrs[kv.Key] = kv.Value
+	}
+	casecheck.Equal(t, "master", *attrs["db.tag"].StringValue)
+	casecheck.Equal(t, "200", *attrs["http.response.status_code"].IntValue)
+}
@@ -0,0 +1,24 @@
+/*
+ *  Copyright (c) 2022-2026 Mikhail Knyazhev <markus621@yandex.com>. All rights reserved.
+ *  Use of this source code is governed by a BSD 3-Clause license that can be found in the LICENSE file.
+ */
+
+package trace
+
+import (
+	"go.osspkg.com/goppy/v3/pkg/env"
+	"go.osspkg.com/goppy/v3/pkg/xc"
+
+	"go.osspkg.com/goppy/v3/plugin"
+)
+
+// WithOTLPExporter sends the spans of the application to the OpenTelemetry collector,
+// the application name is used as the service name
+func WithOTLPExporter() plugin.Kind {
+	return plugin.Kind{
+		Config: &ConfigGroup{},
+		Inject: func(ctx xc.Context, name env.AppName, c *ConfigGroup) *OTLPExporter {
+			return NewOTLPExporter(ctx.Context(), string(name), c.Trace)
+		},
+	}
+}
@@ -0,0 +1,99 @@
+/*
+ *  Copyright (c) 2022-2026 Mikhail Knyazhev <markus621@yandex.com>. All rights reserved.
+ *  Use of this source code is governed by a BSD 3-Clause license that can be found in the LICENSE file.
+ */
+
+package trace
+
+import (
+	"context"
+	"encoding/hex"
+	"net/http"
+	"strings"
+)
+
+// HeaderTraceparent W3C Trace Context header
+const HeaderTraceparent = "Traceparent"
+
+const (
+	traceparentVersion = "00"
+	traceparentLen     = 55
+	flagSampled        = 0x01
+)
+
+// Traceparent formats the span context as the W3C traceparent value: version-traceid-spanid-flags
+func (v SpanContext) Traceparent() string {
+	flags := "00"
+	if v.Sampled {
+		flags = "01"
+	}
+	return traceparentVersion + "-" + v.TraceID.String() + "-" + v.SpanID.String() + "-" + flags
+}
+
+// ParseTraceparent parses the W3C traceparent value, the result is marked as remote
+func ParseTraceparent(value string) (SpanContext, bool) {
+	var sc SpanContext
+
+	value = strings.TrimSpace(value)
+	if len(value) < traceparentLen {
+		return sc, false
+	}
+
+	parts := strings.SplitN(value, "-", 5)
+	if len(parts) < 4 || len(parts[0]) != 2 || parts[0] == "ff" {
+		return sc, false
+	}
+	// version 00 has exactly four fields, the future versions can add more
+	if parts[0] == traceparentVersion && (len(parts) != 4 || len(value) != traceparentLen) {
+		return sc, false
+	}
+	if len(parts[1]) != 32 || len(parts[2]) != 16 || len(parts[3]) < 2 {
+		return sc, false
+	}
+	if !isLowerHex(parts[0]) || !isLowerHex(parts[1]) || !isLowerHex(parts[2]) || !isLowerHex(parts[3][:2]) {
+		return sc, false
+	}
+
+	var flags [1]byte
+	if _, err := hex.Decode(sc.TraceID[:], []byte(parts[1])); err != nil {
+		return sc, false
+	}
+	if _, err := hex.Decode(sc.SpanID[:], []byte(parts[2])); err != nil {
+		return sc, false
+	}
+	if _, err := hex.Decode(flags[:], []byte(parts[3][:2])); err != nil {
+		return sc, false
+	}
+
+	sc.Sampled = flags[0]&flagSampled == flagSampled
+	sc.Remote = true
+
+	return sc, sc.IsValid()
+}
+
+// Inject writes the traceparent header of the span in the context
+func Inject(ctx context.Context, head http.Header) {
+	if sc := SpanFromContext(ctx).Context(); sc.IsValid() {
+		head.Set(HeaderTraceparent, sc.Traceparent())
+	}
+}
+
+// Extract returns a copy of the context with the remote parent from the traceparent header,
+// the context is returned as is if the header is missing or invalid
+func Extract(ctx context.Context, head http.Header) context.Context {
+	sc, ok := ParseTraceparent(head.Get(HeaderTraceparent))
+	if !ok {
+		return ctx
+	}
+	return ContextWithSpan(ctx, noopSpan{sc: sc})
+}
+
+func isLowerHex(s string) bool {
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if (c < '0' || c > '9') && (c < 'a' || c > 'f') {
+			return false
+		}
+	}
+	return true
+}
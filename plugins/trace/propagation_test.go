@@ -0,0 +1,63 @@
+/*
+ *  Copyright (c) 2022-2026 Mikhail Knyazhev <markus621@yandex.com>. All rights reserved.
+ *  Use of this source code is governed by a BSD 3-Clause license that can be found in the LICENSE file.
+ */
+
+package trace
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"go.osspkg.com/casecheck"
+)
+
+func TestUnit_ParseTraceparent(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    bool
+		sampled bool
+	}{
+		{name: "sampled", value: "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01", want: true, sampled: true},
+		{name: "not sampled", value: "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-00", want: true},
+		{name: "future version", value: "cc-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01-extra", want: true, sampled: true},
+		{name: "extra field v00", value: "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01-extra"},
+		{name: "invalid version", value: "ff-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"},
+		{name: "zero trace", value: "00-00000000000000000000000000000000-00f067aa0ba902b7-01"},
+		{name: "zero span", value: "00-4bf92f3577b34da6a3ce929d0e0e4736-0000000000000000-01"},
+		{name: "upper case", value: "00-4BF92F3577B34DA6A3CE929D0E0E4736-00f067aa0ba902b7-01"},
+		{name: "short", value: "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7"},
+		{name: "empty", value: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sc, ok := ParseTraceparent(tt.value)
+			casecheck.Equal(t, tt.want, ok)
+			if !ok {
+				return
+			}
+			casecheck.True(t, sc.Remote)
+			casecheck.Equal(t, tt.sampled, sc.Sampled)
+			casecheck.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", sc.TraceID.String())
+			casecheck.Equal(t, "00f067aa0ba902b7", sc.SpanID.String())
+		})
+	}
+}
+
+func TestUnit_InjectExtract(t *testing.T) {
+	const value = "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+
+	in := http.Header{}
+	in.Set(HeaderTraceparent, value)
+	ctx := Extract(context.Background(), in)
+
+	out := http.Header{}
+	Inject(ctx, out)
+	casecheck.Equal(t, value, out.Get(HeaderTraceparent))
+
+	out = http.Header{}
+	Inject(Extract(context.Background(), http.Header{}), out)
+	casecheck.Equal(t, "", out.Get(HeaderTraceparent))
+}
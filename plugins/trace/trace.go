@@ -0,0 +1,206 @@
+/*
+ *  Copyright (c) 2022-2026 Mikhail Knyazhev <markus621@yandex.com>. All rights reserved.
+ *  Use of this source code is governed by a BSD 3-Clause license that can be found in the LICENSE file.
+ */
+
+package trace
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"sync"
+	"time"
+)
+
+type (
+	TraceID [16]byte
+	SpanID  [8]byte
+
+	// SpanContext identity of the span which is propagated between services
+	SpanContext struct {
+		TraceID TraceID
+		SpanID  SpanID
+		Sampled bool
+		Remote  bool
+	}
+
+	Kind int
+
+	// Span single operation of the trace, all methods are safe to call on a not recorded span
+	Span interface {
+		Context() SpanContext
+		SetAttr(key string, value any)
+		SetError(err error)
+		End()
+	}
+)
+
+const (
+	KindInternal Kind = iota + 1
+	KindServer
+	KindClient
+	KindProducer
+	KindConsumer
+)
+
+func (v TraceID) IsValid() bool  { return v != TraceID{} }
+func (v TraceID) String() string { return hex.EncodeToString(v[:]) }
+
+func (v SpanID) IsValid() bool  { return v != SpanID{} }
+func (v SpanID) String() string { return hex.EncodeToString(v[:]) }
+
+func (v SpanContext) IsValid() bool {
+	return v.TraceID.IsValid() && v.SpanID.IsValid()
+}
+
+/**********************************************************************************************************************/
+
+type ctxKey struct{}
+
+// ContextWithSpan returns a copy of the context with the span as the parent of the next spans
+func ContextWithSpan(ctx context.Context, s Span) context.Context {
+	return context.WithValue(ctx, ctxKey{}, s)
+}
+
+// SpanFromContext getting the current span, the span without the valid context is returned if there is none
+func SpanFromContext(ctx context.Context) Span {
+	if s, ok := ctx.Value(ctxKey{}).(Span); ok {
+		return s
+	}
+	return noopSpan{}
+}
+
+// Start creates a child span of the span in the context or a new trace if there is none.
+// Without the exporter the span is not recorded, but the parent trace is still propagated.
+func Start(ctx context.Context, name string, kind Kind) (context.Context, Span) {
+	return start(ctx, SpanFromContext(ctx).Context(), name, kind, nil)
+}
+
+// StartLinked creates a new trace linked to the spans instead of the child of the span in the context,
+// e.g. for the messages of the long-lived connection which should not be the part of its trace
+func StartLinked(ctx context.Context, name string, kind Kind, links ...SpanContext) (context.Context, Span) {
+	valid := make([]SpanContext, 0, len(links))
+	for _, l := range links {
+		if l.IsValid() {
+			valid = append(valid, l)
+		}
+	}
+	// the span of the context is detached, so it is not propagated even if the new span is not recorded
+	return start(ContextWithSpan(ctx, noopSpan{}), SpanContext{}, name, kind, valid)
+}
+
+func start(ctx context.Context, parent SpanContext, name string, kind Kind, links []SpanContext) (context.Context, Span) {
+	exp := getExporter()
+
+	if !parent.IsValid() && exp == nil {
+		return ctx, noopSpan{}
+	}
+
+	sc := SpanContext{
+		TraceID: parent.TraceID,
+		SpanID:  newSpanID(),
+		Sampled: parent.Sampled,
+	}
+	if !parent.IsValid() {
+		sc.TraceID = newTraceID()
+		sc.Sampled = true
+	}
+
+	if !sc.Sampled || exp == nil {
+		s := noopSpan{sc: sc}
+		return ContextWithSpan(ctx, s), s
+	}
+
+	s := &span{
+		exp: exp,
+		data: SpanData{
+			Name:    name,
+			Kind:    kind,
+			TraceID: sc.TraceID,
+			SpanID:  sc.SpanID,
+			Start:   time.Now(),
+			Links:   links,
+		},
+		sc: sc,
+	}
+	if parent.IsValid() {
+		s.data.ParentSpanID = parent.SpanID
+	}
+
+	return ContextWithSpan(ctx, s), s
+}
+
+func newTraceID() (v TraceID) {
+	_, _ = rand.Read(v[:]) //nolint:errcheck
+	return
+}
+
+func newSpanID() (v SpanID) {
+	_, _ = rand.Read(v[:]) //nolint:errcheck
+	return
+}
+
+/**********************************************************************************************************************/
+
+type span struct {
+	sc    SpanContext
+	exp   Exporter
+	data  SpanData
+	ended bool
+	mux   sync.Mutex
+}
+
+func (v *span) Context() SpanContext {
+	return v.sc
+}
+
+func (v *span) SetAttr(key string, value any) {
+	v.mux.Lock()
+	defer v.mux.Unlock()
+
+	if v.ended {
+		return
+	}
+	if v.data.Attrs == nil {
+		v.data.Attrs = make(map[string]any, 4)
+	}
+	v.data.Attrs[key] = value
+}
+
+func (v *span) SetError(err error) {
+	if err == nil {
+		return
+	}
+
+	v.mux.Lock()
+	defer v.mux.Unlock()
+
+	if !v.ended {
+		v.data.Error = err.Error()
+	}
+}
+
+// End exports the span once, next calls do nothing
+func (v *span) End() {
+	v.mux.Lock()
+	if v.ended {
+		v.mux.Unlock()
+		return
+	}
+	v.ended = true
+	v.data.End = time.Now()
+	data := v.data
+	v.mux.Unlock()
+
+	v.exp.Export(data)
+}
+
+type noopSpan struct {
+	sc SpanContext
+}
+
+func (v noopSpan) Context() SpanContext { return v.sc }
+func (noopSpan) SetAttr(string, any)    {}
+func (noopSpan) SetError(error)         {}
+func (noopSpan) End()                   {}
@@ -0,0 +1,92 @@
+/*
+ *  Copyright (c) 2022-2026 Mikhail Knyazhev <markus621@yandex.com>. All rights reserved.
+ *  Use of this source code is governed by a BSD 3-Clause license that can be found in the LICENSE file.
+ */
+
+package trace
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"go.osspkg.com/casecheck"
+)
+
+func TestUnit_Start(t *testing.T) {
+	SetExporter(nil)
+	ctx, span := Start(context.Background(), "no exporter", KindInternal)
+	casecheck.False(t, span.Context().IsValid())
+	casecheck.False(t, SpanFromContext(ctx).Context().IsValid())
+
+	exp := NewMemoryExporter()
+	SetExporter(exp)
+	defer SetExporter(nil)
+
+	ctx, root := Start(context.Background(), "root", KindServer)
+	_, child := Start(ctx, "child", KindClient)
+	child.SetAttr("key", "value")
+	child.SetError(errors.New("fail"))
+	child.End()
+	child.End()
+	root.End()
+
+	spans := exp.Spans()
+	casecheck.Equal(t, 2, len(spans))
+	casecheck.Equal(t, "child", spans[0].Name)
+	casecheck.Equal(t, "fail", spans[0].Error)
+	casecheck.Equal(t, "value", spans[0].Attrs["key"])
+	casecheck.Equal(t, root.Context().TraceID, spans[0].TraceID)
+	casecheck.Equal(t, root.Context().SpanID, spans[0].ParentSpanID)
+	casecheck.Equal(t, "root", spans[1].Name)
+	casecheck.False(t, spans[1].ParentSpanID.IsValid())
+}
+
+func TestUnit_StartLinked(t *testing.T) {
+	exp := NewMemoryExporter()
+	SetExporter(exp)
+	defer SetExporter(nil)
+
+	ctx, conn := Start(context.Background(), "connect", KindServer)
+	ctx, msg := StartLinked(ctx, "message", KindConsumer, conn.Context(), SpanContext{})
+	_, child := Start(ctx, "child", KindInternal)
+	child.End()
+	msg.End()
+	conn.End()
+
+	spans := exp.Spans()
+	casecheck.Equal(t, 3, len(spans))
+	casecheck.Equal(t, msg.Context().TraceID, spans[0].TraceID)
+	casecheck.Equal(t, msg.Context().SpanID, spans[0].ParentSpanID)
+	casecheck.Equal(t, "message", spans[1].Name)
+	casecheck.NotEqual(t, conn.Context().TraceID, spans[1].TraceID)
+	casecheck.False(t, spans[1].ParentSpanID.IsValid())
+	casecheck.Equal(t, []SpanContext{conn.Context()}, spans[1].Links)
+
+	SetExporter(nil)
+	head := http.Header{}
+	head.Set(HeaderTraceparent, "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	ctx, _ = StartLinked(Extract(context.Background(), head), "message", KindConsumer)
+	casecheck.False(t, SpanFromContext(ctx).Context().IsValid())
+}
+
+func TestUnit_StartRemoteParent(t *testing.T) {
+	exp := NewMemoryExporter()
+	SetExporter(exp)
+	defer SetExporter(nil)
+
+	head := http.Header{}
+	head.Set(HeaderTraceparent, "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-00")
+
+	ctx, span := Start(Extract(context.Background(), head), "not sampled", KindServer)
+	span.End()
+
+	casecheck.Equal(t, 0, len(exp.Spans()))
+	casecheck.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", span.Context().TraceID.String())
+	casecheck.NotEqual(t, "00f067aa0ba902b7", span.Context().SpanID.String())
+
+	out := http.Header{}
+	Inject(ctx, out)
+	casecheck.Equal(t, span.Context().Traceparent(), out.Get(HeaderTraceparent))
+}
@@ -19,6 +19,7 @@ import (
 	"go.osspkg.com/ioutils/cache"
 
 	"go.osspkg.com/goppy/v3/plugins/auth/signature"
+	"go.osspkg.com/goppy/v3/plugins/trace"
 	"go.osspkg.com/goppy/v3/plugins/web/client/comparison"
 )
 
@@ -89,6 +90,14 @@ func (cli *httpCli) Send(ctx context.Context, method, url string, in, out any) (
 		url = uri.String()
 	}
 
+	ctx, span := trace.Start(ctx, "HTTP "+method, trace.KindClient)
+	span.SetAttr("http.request.method", method)
+	span.SetAttr("server.address", host)
+	defer func() {
+		span.SetError(err)
+		span.End()
+	}()
+
 	var (
 		contentType string
 		body                        = bb.New(128)
@@ -138,6 +147,7 @@ func (cli *httpCli) Send(ctx context.Context, method, url string, in, out any) (
 			req.Header.Set(head, val)
 		}
 	}
+	trace.Inject(ctx, req.Header)
 
 	if sign, ok := cli.signStore.Get(host); ok && sign != nil {
 		b := make([]byte, 0, body.Size()+len(url))
@@ -157,6 +167,7 @@ func (cli *httpCli) Send(ctx context.Context, method, url string, in, out any) (
 		return fmt.Errorf("http client: failed to send request: %w", err)
 	}
 	defer resp.Body.Close() //nolint:errcheck
+	span.SetAttr("http.response.status_code", resp.StatusCode)
 
 	body.Reset()
 	if _, err = io.Copy(body, resp.Body); err != nil {
@@ -23,6 +23,8 @@ var (
 	errServAlreadyRunning = errors.New("server already running")
 	errServAlreadyStopped = errors.New("server already stopped")
 	errFailContextKey     = errors.New("context key is not found")
+	errRouteNotFound      = errors.New("route name is not found")
+	errRouteParams        = errors.New("invalid route params")
 )
 
 var (
@@ -39,6 +41,12 @@ var (
 
 const urlSplitSeparate = "/"
 
+const (
+	mimeJSON     = "application/json"
+	mimeXML      = "application/xml"
+	mimeFormData = "multipart/form-data"
+)
+
 func urlSplit(uri string) []string {
 	vv := strings.Split(strings.ToLower(uri), urlSplitSeparate)
 	for i := 0; i < len(vv); i++ {
@@ -29,6 +29,15 @@ type Config struct {
 	IdleTimeout     time.Duration        `yaml:"idle_timeout,omitempty"`
 	ShutdownTimeout time.Duration        `yaml:"shutdown_timeout,omitempty"`
 	Tls             []listen.Certificate `yaml:"tls,omitempty"`
+	TrustedProxies  []string             `yaml:"trusted_proxies,omitempty"`
+	// MaxBodySize limits the request body in bytes for Ctx.Bind* methods, zero disables the limit
+	MaxBodySize int64 `yaml:"max_body_size,omitempty"`
+	// StrictJSON rejects unknown fields and trailing data in Ctx.BindJSON
+	StrictJSON bool `yaml:"strict_json,omitempty"`
+	// H2C enables HTTP/2 without TLS for the internal traffic
+	H2C bool `yaml:"h2c,omitempty"`
+	// HTTP3 starts the QUIC listener on the same UDP port, requires TLS
+	HTTP3 bool `yaml:"http3,omitempty"`
 }
 
 func (v *ConfigGroup) Default() {
@@ -61,6 +70,18 @@ func (v *ConfigGroup) Validate() error {
 				)
 			}
 		}
+		if cfg.HTTP3 && len(cfg.Tls) == 0 {
+			return fmt.Errorf("http server: http3 requires tls (config=%d)", i)
+		}
+		if cfg.HTTP3 && len(cfg.Network) > 0 && !strings.HasPrefix(cfg.Network, "tcp") {
+			return fmt.Errorf("http server: http3 requires tcp network (config=%d)", i)
+		}
+		if cfg.MaxBodySize < 0 {
+			return fmt.Errorf("http server: max body size must not be negative (config=%d)", i)
+		}
+		if _, err := parseTrustedProxies(cfg.TrustedProxies); err != nil {
+			return fmt.Errorf("http server: %w (config=%d)", err, i)
+		}
 		if cfg.Tls != nil {
 			for _, cert := range cfg.Tls {
 				if !fs.FileExist(cert.CAFile) {
@@ -8,15 +8,19 @@ package web
 //go:generate easyjson
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"io"
+	"mime/multipart"
+	"net"
 	"net/http"
 	"net/url"
 	"slices"
 	"strconv"
 
 	"go.osspkg.com/bb"
+	"go.osspkg.com/errors"
 	"go.osspkg.com/ioutils"
 	"go.osspkg.com/logx"
 
@@ -25,8 +29,18 @@ import (
 
 type (
 	_ctx struct {
-		w http.ResponseWriter
-		r *http.Request
+		w       http.ResponseWriter
+		r       *http.Request
+		opts    ctxOptions
+		limited bool
+		pattern string
+	}
+
+	ctxOptions struct {
+		tag         string
+		proxies     trustedProxies
+		maxBodySize int64
+		strictJSON  bool
 	}
 
 	// Ctx request and response interface
@@ -42,13 +56,18 @@ type (
 		BindBytes(in *[]byte) error
 		BindJSON(in any) error
 		BindXML(in any) error
+		BindFormData(maxMemory int64, in any) error
+		MultipartForm(maxMemory int64) (*multipart.Form, error)
+		FormFile(key string, maxMemory int64) (multipart.File, *multipart.FileHeader, error)
 
 		Raw(code int, contentType string, b []byte)
 		Bytes(code int, b []byte)
 		String(code int, b string, args ...any)
 		JSON(code int, in any)
+		Negotiate(code int, in any)
 		Stream(code int, in []byte, filename string)
 		StreamFile(code int, in io.Reader, filename string)
+		SSE(opts ...SSEOption) (SSE, error)
 
 		Error(code int, err error)
 		ErrorJSON(code int, err error, args ...any)
@@ -59,6 +78,9 @@ type (
 
 		Request() *http.Request
 		Response() http.ResponseWriter
+		ClientIP() net.IP
+		ServerTag() string
+		RoutePattern() string
 	}
 )
 
@@ -69,6 +91,14 @@ func NewCtx(w http.ResponseWriter, r *http.Request) Ctx {
 	}
 }
 
+func newCtx(w http.ResponseWriter, r *http.Request, opts ctxOptions) *_ctx {
+	return &_ctx{
+		w:    w,
+		r:    r,
+		opts: opts,
+	}
+}
+
 /**********************************************************************************************************************/
 
 func (v *_ctx) Request() *http.Request {
@@ -79,6 +109,16 @@ func (v *_ctx) Response() http.ResponseWriter {
 	return v.w
 }
 
+// ServerTag tag of the server which handles the request
+func (v *_ctx) ServerTag() string {
+	return v.opts.tag
+}
+
+// RoutePattern pattern of the matched route like `/users/{id}`, empty if the route is not found
+func (v *_ctx) RoutePattern() string {
+	return v.pattern
+}
+
 /**********************************************************************************************************************/
 
 type (
@@ -204,9 +244,12 @@ func (v *_ctx) Cookie() Cookie {
 /**********************************************************************************************************************/
 
 func (v *_ctx) BindBytes(in *[]byte) error {
+	if err := v.limitBody(); err != nil {
+		return err
+	}
 	b, err := ioutils.ReadAll(v.r.Body)
 	if err != nil {
-		return err
+		return bodyError(err)
 	}
 	*in = append(*in, b...)
 	return nil
@@ -216,24 +259,40 @@ func (v *_ctx) BindRaw() (*bb.Buffer, error) {
 	defer func() {
 		v.r.Body.Close() //nolint:errcheck
 	}()
+	if err := v.limitBody(); err != nil {
+		return nil, err
+	}
 	buf := bb.New(128)
 	if _, err := buf.ReadFrom(v.r.Body); err != nil {
-		return nil, err
+		return nil, bodyError(err)
 	}
 	return buf, nil
 }
 
+// BindJSON decoding the body as JSON, in the strict mode unknown fields and trailing data are rejected
 func (v *_ctx) BindJSON(in any) error {
-	return encoders.JSONDecode(v.r, in)
+	if err := v.limitBody(); err != nil {
+		return err
+	}
+	if v.strictJSON() {
+		return bodyError(encoders.JSONDecodeStrict(v.r, in))
+	}
+	return bodyError(encoders.JSONDecode(v.r, in))
 }
 
 func (v *_ctx) BindXML(in any) error {
-	return encoders.XMLDecode(v.r, in)
+	if err := v.limitBody(); err != nil {
+		return err
+	}
+	return bodyError(encoders.XMLDecode(v.r, in))
 }
 
 func (v *_ctx) BindFormData(maxMemory int64, in any) error {
 	defer v.r.Body.Close() //nolint:errcheck
-	return encoders.FormDataDecode(v.r.Body, maxMemory, in)
+	if err := v.limitBody(); err != nil {
+		return err
+	}
+	return bodyError(encoders.FormDataDecode(v.r.Body, maxMemory, in))
 }
 
 /**********************************************************************************************************************/
@@ -264,11 +323,11 @@ func (v *_ctx) ErrorJSON(code int, err error, args ...any) {
 		}
 	}
 
-	encoders.JSONEncode(v.w, code, &model)
+	encoders.JSONEncode(v.w, errorCode(code, err), &model)
 }
 
 func (v *_ctx) Error(code int, err error) {
-	encoders.ErrorEncode(v.w, code, err)
+	encoders.ErrorEncode(v.w, errorCode(code, err), err)
 }
 
 func (v *_ctx) Bytes(code int, b []byte) {
@@ -289,6 +348,29 @@ func (v *_ctx) JSON(code int, in any) {
 	encoders.JSONEncode(v.w, code, in)
 }
 
+// Negotiate recording the response in the format selected by the Accept header:
+// JSON, XML or multipart form, JSON is used if the header is empty,
+// 406 Not Acceptable is returned if nothing is acceptable
+func (v *_ctx) Negotiate(code int, in any) {
+	switch negotiate(v.r.Header.Values("Accept"), mimeJSON, mimeXML, mimeFormData) {
+	case mimeJSON:
+		encoders.JSONEncode(v.w, code, in)
+	case mimeXML:
+		encoders.XMLEncode(v.w, code, in)
+	case mimeFormData:
+		buf := bytes.NewBuffer(nil)
+		contentType, err := encoders.FormDataEncode(buf, in)
+		if err != nil {
+			logx.Error("web.Negotiate", "err", err)
+			v.Error(http.StatusInternalServerError, errors.New(http.StatusText(http.StatusInternalServerError)))
+			return
+		}
+		encoders.RawEncode(v.w, code, contentType, buf.Bytes())
+	default:
+		v.Error(http.StatusNotAcceptable, errors.New(http.StatusText(http.StatusNotAcceptable)))
+	}
+}
+
 // Stream sending raw data in response with the definition of the content type by the file name
 func (v *_ctx) Stream(code int, in []byte, filename string) {
 	encoders.StreamEncode(v.w, code, in, filename)
@@ -350,3 +432,11 @@ func (v *_ctx) Redirect(uri string) {
 }
 
 /**********************************************************************************************************************/
+
+// ClientIP address of the client, the forwarding headers
+// (Forwarded, X-Forwarded-For, X-Real-IP) are used only from the trusted proxies
+func (v *_ctx) ClientIP() net.IP {
+	return v.opts.proxies.ClientIP(v.r)
+}
+
+/**********************************************************************************************************************/
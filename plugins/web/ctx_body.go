@@ -0,0 +1,114 @@
+/*
+ *  Copyright (c) 2022-2026 Mikhail Knyazhev <markus621@yandex.com>. All rights reserved.
+ *  Use of this source code is governed by a BSD 3-Clause license that can be found in the LICENSE file.
+ */
+
+package web
+
+import (
+	"mime/multipart"
+	"net/http"
+
+	"go.osspkg.com/errors"
+)
+
+// ErrBodyTooLarge returned by Ctx.Bind* methods when the request body exceeds the configured limit,
+// Ctx.Error and Ctx.ErrorJSON reply with 413 for it
+var ErrBodyTooLarge = errors.New(http.StatusText(http.StatusRequestEntityTooLarge))
+
+type (
+	bodyLimitKey  struct{}
+	strictJSONKey struct{}
+)
+
+// BodyLimitMiddleware overrides the max body size of the server for the route, zero disables the limit
+func BodyLimitMiddleware(size int64) Middleware {
+	return func(call func(Ctx)) func(Ctx) {
+		return func(ctx Ctx) {
+			ctx.SetContextValue(bodyLimitKey{}, size)
+			call(ctx)
+		}
+	}
+}
+
+// StrictJSONMiddleware enables for the route decoding of JSON that rejects unknown fields and trailing data
+func StrictJSONMiddleware(strict bool) Middleware {
+	return func(call func(Ctx)) func(Ctx) {
+		return func(ctx Ctx) {
+			ctx.SetContextValue(strictJSONKey{}, strict)
+			call(ctx)
+		}
+	}
+}
+
+func (v *_ctx) bodyLimit() int64 {
+	if size, ok := v.GetContextValue(bodyLimitKey{}).(int64); ok {
+		return size
+	}
+	return v.opts.maxBodySize
+}
+
+func (v *_ctx) strictJSON() bool {
+	if strict, ok := v.GetContextValue(strictJSONKey{}).(bool); ok {
+		return strict
+	}
+	return v.opts.strictJSON
+}
+
+// limitBody wraps the request body with the reader which fails after the limit,
+// the body is wrapped only once per request, the declared oversized body is rejected on every call
+func (v *_ctx) limitBody() error {
+	size := v.bodyLimit()
+	if size <= 0 {
+		return nil
+	}
+	if !v.limited {
+		v.r.Body = http.MaxBytesReader(v.w, v.r.Body, size)
+		v.limited = true
+	}
+	if v.r.ContentLength > size {
+		return ErrBodyTooLarge
+	}
+	return nil
+}
+
+func bodyError(err error) error {
+	if err == nil {
+		return nil
+	}
+	var mbe *http.MaxBytesError
+	if errors.As(err, &mbe) {
+		return ErrBodyTooLarge
+	}
+	return err
+}
+
+func errorCode(code int, err error) int {
+	if err != nil && errors.Is(err, ErrBodyTooLarge) {
+		return http.StatusRequestEntityTooLarge
+	}
+	return code
+}
+
+// MultipartForm parsing the multipart form with files, the files over maxMemory are stored on disk
+func (v *_ctx) MultipartForm(maxMemory int64) (*multipart.Form, error) {
+	if err := v.limitBody(); err != nil {
+		return nil, err
+	}
+	if err := v.r.ParseMultipartForm(maxMemory); err != nil {
+		return nil, bodyError(err)
+	}
+	return v.r.MultipartForm, nil
+}
+
+// FormFile getting the first file of the multipart form by key
+func (v *_ctx) FormFile(key string, maxMemory int64) (multipart.File, *multipart.FileHeader, error) {
+	if _, err := v.MultipartForm(maxMemory); err != nil {
+		return nil, nil, err
+	}
+	file, header, err := v.r.FormFile(key)
+	if err != nil {
+		return nil, nil, err
+	}
+	return file, header, nil
+}
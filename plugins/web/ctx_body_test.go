@@ -0,0 +1,135 @@
+/*
+ *  Copyright (c) 2022-2026 Mikhail Knyazhev <markus621@yandex.com>. All rights reserved.
+ *  Use of this source code is governed by a BSD 3-Clause license that can be found in the LICENSE file.
+ */
+
+package web
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"go.osspkg.com/casecheck"
+)
+
+func TestUnit_BodyLimit(t *testing.T) {
+	router := NewBaseRouter()
+	router.MaxBodySize(8)
+	router.Route("/small", func(ctx Ctx) {
+		var b []byte
+		if err := ctx.BindBytes(&b); err != nil {
+			ctx.Error(http.StatusBadRequest, err)
+			return
+		}
+		ctx.Bytes(http.StatusOK, b)
+	}, http.MethodPost)
+	router.Route("/big", func(ctx Ctx) {
+		var b []byte
+		if err := ctx.BindBytes(&b); err != nil {
+			ctx.Error(http.StatusBadRequest, err)
+			return
+		}
+		ctx.Bytes(http.StatusOK, b)
+	}, http.MethodPost)
+	router.Middlewares("/big", BodyLimitMiddleware(16))
+	router.Route("/twice", func(ctx Ctx) {
+		var b []byte
+		err1 := ctx.BindBytes(&b)
+		err2 := ctx.BindBytes(&b)
+		casecheck.True(t, errors.Is(err1, ErrBodyTooLarge))
+		casecheck.True(t, errors.Is(err2, ErrBodyTooLarge))
+		ctx.Error(http.StatusBadRequest, err2)
+	}, http.MethodPost)
+
+	do := func(uri string, body io.Reader) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, uri, body))
+		return w
+	}
+
+	w := do("/small", strings.NewReader("12345678"))
+	casecheck.Equal(t, http.StatusOK, w.Code)
+
+	w = do("/small", strings.NewReader("123456789"))
+	casecheck.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
+
+	w = do("/small", io.MultiReader(strings.NewReader("12345"), strings.NewReader("6789")))
+	casecheck.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
+
+	w = do("/twice", strings.NewReader("123456789"))
+	casecheck.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
+
+	w = do("/twice", io.MultiReader(strings.NewReader("12345"), strings.NewReader("6789")))
+	casecheck.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
+
+	w = do("/big", strings.NewReader("123456789"))
+	casecheck.Equal(t, http.StatusOK, w.Code)
+	casecheck.Equal(t, "123456789", w.Body.String())
+}
+
+func TestUnit_StrictJSON(t *testing.T) {
+	type model struct {
+		Name string `json:"name"`
+	}
+
+	router := NewBaseRouter()
+	router.Route("/", func(ctx Ctx) {
+		var m model
+		if err := ctx.BindJSON(&m); err != nil {
+			ctx.Error(http.StatusBadRequest, err)
+			return
+		}
+		ctx.String(http.StatusOK, m.Name)
+	}, http.MethodPost)
+
+	do := func(body string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body)))
+		return w
+	}
+
+	casecheck.Equal(t, http.StatusOK, do(`{"name":"a","age":1}`).Code)
+
+	router.StrictJSON(true)
+	casecheck.Equal(t, http.StatusOK, do(`{"name":"a"}`).Code)
+	casecheck.Equal(t, http.StatusOK, do(`{"name":"a"}`+"\n").Code)
+	casecheck.Equal(t, http.StatusBadRequest, do(`{"name":"a","age":1}`).Code)
+	casecheck.Equal(t, http.StatusBadRequest, do(`{"name":"a"}{"name":"b"}`).Code)
+	casecheck.Equal(t, http.StatusBadRequest, do(`{"name":"a"} x`).Code)
+
+	router.Middlewares("/", StrictJSONMiddleware(false))
+	casecheck.Equal(t, http.StatusOK, do(`{"name":"a","age":1}`).Code)
+}
+
+func TestUnit_FormFile(t *testing.T) {
+	body := &bytes.Buffer{}
+	mw := multipart.NewWriter(body)
+	casecheck.NoError(t, mw.WriteField("name", "a"))
+	fw, err := mw.CreateFormFile("file", "a.txt")
+	casecheck.NoError(t, err)
+	_, err = fw.Write([]byte("hello"))
+	casecheck.NoError(t, err)
+	casecheck.NoError(t, mw.Close())
+
+	r := httptest.NewRequest(http.MethodPost, "/", body)
+	r.Header.Set("Content-Type", mw.FormDataContentType())
+	ctx := NewCtx(httptest.NewRecorder(), r)
+
+	form, err := ctx.MultipartForm(1024)
+	casecheck.NoError(t, err)
+	casecheck.Equal(t, []string{"a"}, form.Value["name"])
+
+	file, header, err := ctx.FormFile("file", 1024)
+	casecheck.NoError(t, err)
+	defer file.Close() //nolint:errcheck
+	casecheck.Equal(t, "a.txt", header.Filename)
+	b, err := io.ReadAll(file)
+	casecheck.NoError(t, err)
+	casecheck.Equal(t, "hello", string(b))
+}
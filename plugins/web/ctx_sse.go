@@ -0,0 +1,224 @@
+/*
+ *  Copyright (c) 2022-2026 Mikhail Knyazhev <markus621@yandex.com>. All rights reserved.
+ *  Use of this source code is governed by a BSD 3-Clause license that can be found in the LICENSE file.
+ */
+
+package web
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"go.osspkg.com/errors"
+)
+
+const defaultSSEHeartbeat = 15 * time.Second
+
+var (
+	errSSENotSupported = errors.New("streaming is not supported by the response writer")
+	errSSEClosed       = errors.New("event stream is closed")
+)
+
+type (
+	// SSE writer of the Server-Sent Events stream
+	SSE interface {
+		// LastEventID value of the Last-Event-ID header sent by the client on reconnect
+		LastEventID() string
+		// Send sends the event, the data is sent as is for string and []byte, otherwise as JSON
+		Send(e SSEEvent) error
+		// Retry sends the reconnection time hint to the client
+		Retry(d time.Duration) error
+		// Comment sends the comment line which is ignored by the client
+		Comment(text string) error
+		// Done closed when the request, the bound context or the stream is closed
+		Done() <-chan struct{}
+		// Close stops the stream and waits for the heartbeat, it must be called before the handler returns
+		Close()
+	}
+
+	// SSEEvent single event of the stream
+	SSEEvent struct {
+		ID    string
+		Event string
+		Data  any
+	}
+
+	SSEOption func(o *sseOptions)
+
+	sseOptions struct {
+		heartbeat time.Duration
+		ctx       context.Context
+	}
+)
+
+// SSEHeartbeat interval of the heartbeat comments which keep the connection alive, 0 disables heartbeat
+func SSEHeartbeat(arg time.Duration) SSEOption {
+	return func(o *sseOptions) {
+		o.heartbeat = max(arg, 0)
+	}
+}
+
+// SSEContext binds the stream to the context, for example xc.Context of the application,
+// the stream is closed when the context is done
+func SSEContext(arg context.Context) SSEOption {
+	return func(o *sseOptions) {
+		if arg != nil {
+			o.ctx = arg
+		}
+	}
+}
+
+type sse struct {
+	w      http.ResponseWriter
+	rc     *http.ResponseController
+	lastID string
+	ctx    context.Context
+	cancel context.CancelFunc
+	closed bool
+	mux    sync.Mutex
+	wg     sync.WaitGroup
+}
+
+// SSE starts the Server-Sent Events stream, the write deadline of the server is disabled for the stream
+func (v *_ctx) SSE(opts ...SSEOption) (SSE, error) {
+	o := &sseOptions{heartbeat: defaultSSEHeartbeat}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	rc := http.NewResponseController(v.w)
+	if err := rc.SetWriteDeadline(time.Time{}); err != nil && !errors.Is(err, http.ErrNotSupported) {
+		return nil, err
+	}
+
+	h := v.w.Header()
+	h.Set("Content-Type", "text/event-stream; charset=utf-8")
+	h.Set("Cache-Control", "no-cache")
+	h.Set("X-Accel-Buffering", "no")
+	v.w.WriteHeader(http.StatusOK)
+
+	if err := rc.Flush(); err != nil {
+		return nil, errors.Wrap(errSSENotSupported, err)
+	}
+
+	obj := &sse{
+		w:      v.w,
+		rc:     rc,
+		lastID: v.r.Header.Get("Last-Event-ID"),
+	}
+	obj.ctx, obj.cancel = context.WithCancel(v.r.Context())
+	if o.ctx != nil {
+		stop := context.AfterFunc(o.ctx, obj.cancel)
+		context.AfterFunc(obj.ctx, func() { stop() })
+	}
+	if o.heartbeat > 0 {
+		obj.wg.Add(1)
+		go obj.heartbeat(o.heartbeat)
+	}
+
+	return obj, nil
+}
+
+func (v *sse) heartbeat(interval time.Duration) {
+	defer v.wg.Done()
+
+	tick := time.NewTicker(interval)
+	defer tick.Stop()
+
+	for {
+		select {
+		case <-v.ctx.Done():
+			return
+		case <-tick.C:
+			if err := v.Comment("ping"); err != nil {
+				v.cancel()
+				return
+			}
+		}
+	}
+}
+
+func (v *sse) LastEventID() string {
+	return v.lastID
+}
+
+func (v *sse) Done() <-chan struct{} {
+	return v.ctx.Done()
+}
+
+// Close cancels the stream and waits for the heartbeat and the current write,
+// so nothing is written to the response after the handler returns
+func (v *sse) Close() {
+	v.cancel()
+	v.wg.Wait()
+
+	v.mux.Lock()
+	defer v.mux.Unlock()
+
+	v.closed = true
+}
+
+func (v *sse) Send(e SSEEvent) error {
+	var data []byte
+	switch val := e.Data.(type) {
+	case nil:
+	case []byte:
+		data = val
+	case string:
+		data = []byte(val)
+	default:
+		b, err := json.Marshal(val)
+		if err != nil {
+			return fmt.Errorf("encode event data: %w", err)
+		}
+		data = b
+	}
+
+	buf := bytes.NewBuffer(make([]byte, 0, len(data)+64))
+	if len(e.ID) > 0 {
+		buf.WriteString("id: " + sseLine(e.ID) + "\n")
+	}
+	if len(e.Event) > 0 {
+		buf.WriteString("event: " + sseLine(e.Event) + "\n")
+	}
+	for _, line := range bytes.Split(data, []byte("\n")) {
+		buf.WriteString("data: ")
+		buf.Write(bytes.TrimSuffix(line, []byte("\r")))
+		buf.WriteString("\n")
+	}
+	buf.WriteString("\n")
+
+	return v.write(buf.Bytes())
+}
+
+func (v *sse) Retry(d time.Duration) error {
+	return v.write([]byte("retry: " + strconv.FormatInt(d.Milliseconds(), 10) + "\n\n"))
+}
+
+func (v *sse) Comment(text string) error {
+	return v.write([]byte(": " + sseLine(text) + "\n\n"))
+}
+
+func (v *sse) write(b []byte) error {
+	v.mux.Lock()
+	defer v.mux.Unlock()
+
+	if v.closed || v.ctx.Err() != nil {
+		return errSSEClosed
+	}
+	if _, err := v.w.Write(b); err != nil {
+		return err
+	}
+	return v.rc.Flush()
+}
+
+func sseLine(s string) string {
+	return strings.NewReplacer("\r", "", "\n", " ").Replace(s)
+}
@@ -0,0 +1,90 @@
+/*
+ *  Copyright (c) 2022-2026 Mikhail Knyazhev <markus621@yandex.com>. All rights reserved.
+ *  Use of this source code is governed by a BSD 3-Clause license that can be found in the LICENSE file.
+ */
+
+package web
+
+import (
+	"bufio"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"go.osspkg.com/casecheck"
+)
+
+func TestUnit_Ctx_SSE(t *testing.T) {
+	appCtx, appCancel := context.WithCancel(context.Background())
+	defer appCancel()
+
+	closed := make(chan struct{})
+
+	r := NewBaseRouter()
+	r.Route("/events", func(ctx Ctx) {
+		stream, err := ctx.SSE(SSEHeartbeat(20*time.Millisecond), SSEContext(appCtx))
+		casecheck.NoError(t, err)
+		defer close(closed)
+		defer stream.Close()
+
+		casecheck.NoError(t, stream.Retry(3*time.Second))
+		casecheck.NoError(t, stream.Send(SSEEvent{ID: stream.LastEventID() + "1", Event: "message", Data: "a\nb"}))
+		casecheck.NoError(t, stream.Send(SSEEvent{ID: "2", Data: map[string]int{"x": 1}}))
+
+		<-stream.Done()
+		casecheck.Error(t, stream.Comment("late"))
+	}, http.MethodGet)
+
+	srv := httptest.NewServer(r)
+	defer srv.Close()
+
+	req, err := http.NewRequest(http.MethodGet, srv.URL+"/events", nil)
+	casecheck.NoError(t, err)
+	req.Header.Set("Last-Event-ID", "0")
+
+	resp, err := http.DefaultClient.Do(req)
+	casecheck.NoError(t, err)
+	defer resp.Body.Close() //nolint:errcheck
+	casecheck.Equal(t, "text/event-stream; charset=utf-8", resp.Header.Get("Content-Type"))
+
+	reader := bufio.NewReader(resp.Body)
+	var lines []string
+	for len(lines) < 11 {
+		line, err := reader.ReadString('\n')
+		casecheck.NoError(t, err)
+		lines = append(lines, strings.TrimSuffix(line, "\n"))
+	}
+	casecheck.Equal(t, []string{
+		"retry: 3000", "",
+		"id: 01", "event: message", "data: a", "data: b", "",
+		"id: 2", `data: {"x":1}`, "",
+		": ping",
+	}, lines)
+
+	appCancel()
+	select {
+	case <-closed:
+	case <-time.After(time.Second):
+		t.Fatal("stream is not closed by the context")
+	}
+}
+
+func TestUnit_Ctx_SSE_Close(t *testing.T) {
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodGet, "/events", nil)
+
+	stream, err := NewCtx(w, r).SSE(SSEHeartbeat(time.Millisecond))
+	casecheck.NoError(t, err)
+	casecheck.Equal(t, "", w.Header().Get("Connection"))
+
+	time.Sleep(10 * time.Millisecond)
+	stream.Close()
+
+	size := w.Body.Len()
+	time.Sleep(10 * time.Millisecond)
+	casecheck.Equal(t, size, w.Body.Len())
+	casecheck.Error(t, stream.Comment("late"))
+}
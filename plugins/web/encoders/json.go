@@ -7,6 +7,8 @@ package encoders
 
 import (
 	"encoding/json"
+	"fmt"
+	"io"
 	"net/http"
 
 	"go.osspkg.com/ioutils"
@@ -34,3 +36,20 @@ func JSONDecode(r *http.Request, obj any) error {
 	}
 	return json.Unmarshal(b, obj)
 }
+
+// JSONDecodeStrict decoding with rejection of unknown fields and trailing data,
+// types with custom UnmarshalJSON are responsible for unknown fields themselves
+func JSONDecodeStrict(r *http.Request, obj any) error {
+	dec := json.NewDecoder(r.Body)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(obj); err != nil {
+		return err
+	}
+	if _, err := dec.Token(); err != io.EOF {
+		if err != nil {
+			return err
+		}
+		return fmt.Errorf("json: unexpected data after top-level value")
+	}
+	return nil
+}
@@ -6,6 +6,7 @@
 package jsonrpc
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
@@ -13,6 +14,7 @@ import (
 
 	"github.com/google/uuid"
 
+	"go.osspkg.com/goppy/v3/plugins/trace"
 	"go.osspkg.com/goppy/v3/plugins/web/client"
 	"go.osspkg.com/goppy/v3/plugins/web/client/comparison"
 )
@@ -21,6 +23,7 @@ type Client struct {
 	address string
 	cli     client.HTTPClient
 	genID   func() string
+	strict  bool
 }
 
 func New(address string, opts ...Opt) *Client {
@@ -41,6 +44,7 @@ func New(address string, opts ...Opt) *Client {
 		address: address,
 		cli:     client.NewHTTPClient(cliOpts.httpopts...),
 		genID:   cliOpts.genID,
+		strict:  cliOpts.strict,
 	}
 }
 
@@ -68,11 +72,19 @@ type Chunk struct {
 	Error  error
 }
 
-func (c *Client) BulkCall(ctx context.Context, bulk ...*Chunk) error {
+func (c *Client) BulkCall(ctx context.Context, bulk ...*Chunk) (err error) {
 	if len(bulk) == 0 {
 		return nil
 	}
 
+	ctx, span := trace.Start(ctx, spanName(bulk), trace.KindClient)
+	span.SetAttr("rpc.system", "jsonrpc")
+	span.SetAttr("rpc.batch_size", len(bulk))
+	defer func() {
+		span.SetError(err)
+		span.End()
+	}()
+
 	req := poolRequestAny.Get()
 	defer poolRequestAny.Put(req)
 	res := poolResponseRaw.Get()
@@ -84,13 +96,18 @@ func (c *Client) BulkCall(ctx context.Context, bulk ...*Chunk) error {
 		id := c.genID()
 		ids[id] = ch
 		*req = append(*req, requestAny{
-			Id:     id,
-			Method: ch.Method,
-			Params: ch.Params,
+			Version: c.version(),
+			Id:      id,
+			Method:  ch.Method,
+			Params:  ch.Params,
 		})
 	}
 
-	if err := c.cli.Send(ctx, http.MethodPost, c.address, req, &res); err != nil {
+	if c.strict {
+		if err := c.sendStrict(ctx, req, res); err != nil {
+			return err
+		}
+	} else if err := c.cli.Send(ctx, http.MethodPost, c.address, req, &res); err != nil {
 		return err
 	}
 
@@ -117,6 +134,45 @@ func (c *Client) BulkCall(ctx context.Context, bulk ...*Chunk) error {
 	return nil
 }
 
+func spanName(bulk []*Chunk) string {
+	if len(bulk) == 1 {
+		return "JSON-RPC " + bulk[0].Method
+	}
+	return "JSON-RPC batch"
+}
+
+func (c *Client) version() string {
+	if c.strict {
+		return Version
+	}
+	return ""
+}
+
+// sendStrict handles a single error object that a strict server returns
+// instead of an array when the whole batch was rejected
+func (c *Client) sendStrict(ctx context.Context, req *bulkRequestAny, res *bulkResponseRaw) error {
+	var raw json.RawMessage
+	if err := c.cli.Send(ctx, http.MethodPost, c.address, req, &raw); err != nil {
+		return err
+	}
+
+	raw = bytes.TrimSpace(raw)
+	if len(raw) == 0 || raw[0] == '[' {
+		return res.UnmarshalJSON(raw)
+	}
+
+	var one responseRaw
+	if err := one.UnmarshalJSON(raw); err != nil {
+		return err
+	}
+	if one.Error != nil {
+		return one.Error
+	}
+
+	*res = append(*res, one)
+	return nil
+}
+
 type ModelAdapter[T any] struct {
 	Data T
 }
@@ -16,6 +16,7 @@ type Opt func(o *cliopts)
 type cliopts struct {
 	genID    func() string
 	httpopts []client.HTTPOption
+	strict   bool
 }
 
 func SetGenID(arg func() string) Opt {
@@ -50,3 +51,10 @@ func SetUnixSocket(path string) Opt {
 		o.httpopts = append(o.httpopts, client.WithUnixSocket(path))
 	}
 }
+
+// SetStrictSpec sends requests and reads responses according to the JSON-RPC 2.0 specification
+func SetStrictSpec() Opt {
+	return func(o *cliopts) {
+		o.strict = true
+	}
+}
@@ -4,12 +4,14 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"net/http/httptest"
 	"net/http/httputil"
 	"testing"
 	"time"
 
 	"go.osspkg.com/casecheck"
 
+	"go.osspkg.com/goppy/v3/plugins/trace"
 	"go.osspkg.com/goppy/v3/plugins/web/jsonrpc"
 )
 
@@ -50,3 +52,33 @@ func TestUnit_Client_Call(t *testing.T) {
 
 	fmt.Println(out.Data)
 }
+
+func TestUnit_Client_Trace(t *testing.T) {
+	exp := trace.NewMemoryExporter()
+	trace.SetExporter(exp)
+	defer trace.SetExporter(nil)
+
+	var traceparent string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		traceparent = r.Header.Get(trace.HeaderTraceparent)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`[{"id":"111","result":[1,2,3]}]`)) //nolint:errcheck
+	}))
+	defer srv.Close()
+
+	cli := jsonrpc.New(srv.URL, jsonrpc.SetGenID(func() string { return "111" }))
+
+	out := jsonrpc.ModelAdapter[[]int]{}
+	casecheck.NoError(t, cli.Call(context.Background(), "app.user", jsonrpc.ModelAdapter[int]{}, &out))
+
+	spans := exp.Spans()
+	casecheck.Equal(t, 2, len(spans))
+	casecheck.Equal(t, "HTTP POST", spans[0].Name)
+	casecheck.Equal(t, "JSON-RPC app.user", spans[1].Name)
+	casecheck.Equal(t, spans[1].SpanID, spans[0].ParentSpanID)
+
+	sc, ok := trace.ParseTraceparent(traceparent)
+	casecheck.True(t, ok)
+	casecheck.Equal(t, spans[0].TraceID, sc.TraceID)
+	casecheck.Equal(t, spans[0].SpanID, sc.SpanID)
+}
@@ -9,14 +9,30 @@ import (
 	"errors"
 
 	"go.osspkg.com/ioutils/pool"
-	"go.osspkg.com/syncing"
+)
+
+// Version of the protocol sent in the `jsonrpc` member in strict mode
+const Version = "2.0"
+
+// Error codes defined by the JSON-RPC 2.0 specification
+const (
+	CodeParseError     int64 = -32700
+	CodeInvalidRequest int64 = -32600
+	CodeMethodNotFound int64 = -32601
+	CodeInvalidParams  int64 = -32602
+	CodeInternalError  int64 = -32603
 )
 
 var (
 	ErrUnsupportedMethod = errors.New("unsupported method")
 	ErrNoResponse        = errors.New("no response")
+	ErrInvalidParams     = errors.New("invalid params")
+	ErrBatchSize         = errors.New("batch size exceeds limit")
+	ErrHandlerPanic      = errors.New("internal error")
 )
 
+const defaultWorkers = 16
+
 var (
 	poolRequestRaw = pool.New[*bulkRequestRaw](func() *bulkRequestRaw {
 		br := make(bulkRequestRaw, 0, 2)
@@ -32,8 +48,4 @@ var (
 		br := make(bulkResponseRaw, 0, 2)
 		return &br
 	})
-
-	poolResponseAnySync = pool.New[*syncing.Slice[responseAny]](func() *syncing.Slice[responseAny] {
-		return syncing.NewSlice[responseAny](uint(2))
-	})
 )
@@ -8,6 +8,7 @@ package jsonrpc
 import (
 	"context"
 	"encoding/json"
+	"time"
 
 	"go.osspkg.com/goppy/v3/plugins/web"
 )
@@ -18,9 +19,30 @@ type TError interface {
 	GetContext() map[string]string
 }
 
+// ObserveFunc receives the route tag, method, duration and error of the handled method
+type ObserveFunc func(tag, method string, duration time.Duration, err error)
+
 type THandleFunc func(ctx context.Context, wc web.Ctx, p json.RawMessage) (any, error)
 
 type TApi interface {
 	JSONRPCApiHandlers() map[string]THandleFunc
 	RouteTags() []string
 }
+
+// TModel request and response models of the method, used to describe the API
+type TModel struct {
+	Request  any
+	Response any
+}
+
+// TApiModels optional interface of TApi which provides models of the methods
+type TApiModels interface {
+	JSONRPCApiModels() map[string]TModel
+}
+
+// TDescription description of the transport registered for the route tag
+type TDescription struct {
+	Path   string
+	Strict bool
+	Models map[string]TModel
+}
@@ -6,8 +6,12 @@
 package jsonrpc
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
+	"net/http"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	"go.osspkg.com/logx"
@@ -18,24 +22,30 @@ import (
 
 type Transport interface {
 	Add(r TApi)
+	Describe(tag string) TDescription
+	Observe(call ObserveFunc)
 }
 
 type service struct {
 	opt      *options
 	handlers map[string]*syncing.Map[string, THandleFunc]
+	models   map[string]map[string]TModel
 	routes   web.ServerPool
 }
 
 func newService(routes web.ServerPool, opts ...Option) Transport {
 	obj := &service{
 		opt: &options{
-			timeout: time.Second * 5,
-			path:    "/jsonrpc",
+			timeout:       time.Second * 5,
+			path:          "/jsonrpc",
+			workers:       defaultWorkers,
+			methodTimeout: make(map[string]time.Duration, 2),
 			errHandler: func(_ string, err error) error {
 				return err
 			},
 		},
 		handlers: make(map[string]*syncing.Map[string, THandleFunc], 2),
+		models:   make(map[string]map[string]TModel, 2),
 		routes:   routes,
 	}
 
@@ -85,10 +95,50 @@ func (v *service) Add(r TApi) {
 		for method, handler := range r.JSONRPCApiHandlers() {
 			resolve.Set(method, handler)
 		}
+
+		rm, ok := r.(TApiModels)
+		if !ok {
+			continue
+		}
+		models, ok := v.models[tag]
+		if !ok {
+			models = make(map[string]TModel, 10)
+			v.models[tag] = models
+		}
+		for method, model := range rm.JSONRPCApiModels() {
+			models[method] = model
+		}
+	}
+}
+
+// Observe sets the func called after each registered method handler with its duration and error,
+// it must be set before the transport is started
+func (v *service) Observe(call ObserveFunc) {
+	v.opt.observe = call
+}
+
+// Describe returns the path, mode and method models registered for the route tag
+func (v *service) Describe(tag string) TDescription {
+	result := TDescription{
+		Path:   v.opt.path,
+		Strict: v.opt.strict,
+		Models: make(map[string]TModel, len(v.models[tag])),
 	}
+	if resolve, ok := v.handlers[tag]; ok {
+		for _, method := range resolve.Keys() {
+			result.Models[method] = v.models[tag][method]
+		}
+	}
+	return result
 }
 
 func (v *service) Handle(resolve *syncing.Map[string, THandleFunc]) func(wc web.Ctx) {
+	if v.opt.strict {
+		return func(wc web.Ctx) {
+			v.handleStrict(wc, resolve)
+		}
+	}
+
 	return func(wc web.Ctx) {
 		req := poolRequestRaw.Get()
 		defer poolRequestRaw.Put(req)
@@ -98,46 +148,200 @@ func (v *service) Handle(resolve *syncing.Map[string, THandleFunc]) func(wc web.
 			return
 		}
 
-		res := poolResponseAnySync.Get()
-		defer poolResponseAnySync.Put(res)
+		if v.opt.maxBatch > 0 && len(*req) > v.opt.maxBatch {
+			wc.String(400, v.opt.errHandler("", ErrBatchSize).Error())
+			return
+		}
+
+		res := make(bulkResponseAny, len(*req))
 
 		ctx, cancel := context.WithTimeout(wc.Context(), v.opt.timeout)
 		defer cancel()
 
-		wg := syncing.NewGroup(ctx)
-		wg.OnPanic(func(err error) {
-			logx.Error("json-rpc handle panic", "err", err)
+		v.batch(ctx, len(*req), func(ctx context.Context, i int) {
+			item := (*req)[i]
+			res[i].Id = item.Id
+
+			result, err := v.call(ctx, wc, resolve, strings.ToLower(item.Method), item.Params)
+			if err != nil {
+				res[i].Error = errConvert(err)
+			} else {
+				res[i].Result = result
+			}
 		})
 
-		for _, item := range *req {
-			item := item
+		wc.JSON(200, res)
+	}
+}
 
-			method := strings.ToLower(item.Method)
+// batch executes the call for each index of the batch, running no more than
+// the configured number of workers at the same time
+func (v *service) batch(ctx context.Context, size int, call func(ctx context.Context, i int)) {
+	var next atomic.Int64
 
-			wg.Background(method, func(ctx context.Context) {
-				out := responseAny{
-					Id: item.Id,
+	wg := syncing.NewGroup(ctx)
+	wg.OnPanic(func(err error) {
+		logx.Error("json-rpc handle panic", "err", err)
+	})
+
+	for range min(size, v.opt.workers) {
+		wg.Background("json-rpc worker", func(ctx context.Context) {
+			for {
+				i := int(next.Add(1) - 1)
+				if i >= size {
+					return
 				}
+				call(ctx, i)
+			}
+		})
+	}
+
+	wg.Wait()
+}
 
-				if handler, ok := resolve.Get(method); ok {
+// call runs the method handler with its own timeout,
+// a panic in the handler is returned as ErrHandlerPanic
+func (v *service) call(
+	ctx context.Context, wc web.Ctx,
+	resolve *syncing.Map[string, THandleFunc], method string, params json.RawMessage,
+) (result any, err error) {
+	handler, ok := resolve.Get(method)
+	if !ok {
+		return nil, ErrUnsupportedMethod
+	}
 
-					result, err := handler(ctx, wc, item.Params)
-					if err != nil {
-						out.Error = errConvert(v.opt.errHandler(method, err))
-					} else {
-						out.Result = result
-					}
+	if err = ctx.Err(); err != nil {
+		return nil, v.opt.errHandler(method, err)
+	}
 
-				} else {
-					out.Error = errConvert(ErrUnsupportedMethod)
-				}
+	if timeout, ok := v.opt.methodTimeout[method]; ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
 
-				res.Append(out)
-			})
+	if v.opt.observe != nil {
+		start := time.Now()
+		defer func() {
+			v.opt.observe(wc.ServerTag(), method, time.Since(start), err)
+		}()
+	}
 
+	defer func() {
+		if e := recover(); e != nil {
+			logx.Error("json-rpc handle panic", "method", method, "err", e)
+			result, err = nil, v.opt.errHandler(method, ErrHandlerPanic)
 		}
+	}()
+
+	if result, err = handler(ctx, wc, params); err != nil {
+		return nil, v.opt.errHandler(method, err)
+	}
 
-		wg.Wait()
-		wc.JSON(200, bulkResponseAny(res.Extract()))
+	return result, nil
+}
+
+func (v *service) handleStrict(wc web.Ctx, resolve *syncing.Map[string, THandleFunc]) {
+	var body []byte
+	if err := wc.BindBytes(&body); err != nil {
+		wc.String(400, v.opt.errHandler("", err).Error())
+		return
+	}
+
+	body = bytes.TrimSpace(body)
+	isBatch := len(body) > 0 && body[0] == '['
+
+	var items []json.RawMessage
+	switch {
+	case isBatch:
+		if err := json.Unmarshal(body, &items); err != nil {
+			wc.JSON(200, errStrictResponse(nil, CodeParseError, "Parse error"))
+			return
+		}
+		if len(items) == 0 {
+			wc.JSON(200, errStrictResponse(nil, CodeInvalidRequest, "Invalid Request"))
+			return
+		}
+	case json.Valid(body):
+		items = append(items, body)
+	default:
+		wc.JSON(200, errStrictResponse(nil, CodeParseError, "Parse error"))
+		return
+	}
+
+	if v.opt.maxBatch > 0 && len(items) > v.opt.maxBatch {
+		wc.JSON(200, errStrictResponse(nil, CodeInvalidRequest, ErrBatchSize.Error()))
+		return
+	}
+
+	result := make([]*responseStrict, len(items))
+
+	ctx, cancel := context.WithTimeout(wc.Context(), v.opt.timeout)
+	defer cancel()
+
+	v.batch(ctx, len(items), func(ctx context.Context, i int) {
+		result[i] = v.callStrict(ctx, wc, resolve, items[i])
+	})
+
+	res := make(bulkResponseStrict, 0, len(result))
+	for _, item := range result {
+		if item != nil {
+			res = append(res, *item)
+		}
+	}
+
+	switch {
+	case len(res) == 0:
+		wc.Response().WriteHeader(http.StatusNoContent)
+	case isBatch:
+		wc.JSON(200, res)
+	default:
+		wc.JSON(200, res[0])
+	}
+}
+
+func (v *service) callStrict(
+	ctx context.Context, wc web.Ctx,
+	resolve *syncing.Map[string, THandleFunc], raw json.RawMessage,
+) *responseStrict {
+	var item requestStrict
+	if err := json.Unmarshal(raw, &item); err != nil || !item.Validate() {
+		var id json.RawMessage
+		if validStrictID(item.Id) {
+			id = item.Id
+		}
+		return errStrictResponse(id, CodeInvalidRequest, "Invalid Request")
+	}
+
+	out := &responseStrict{
+		Version: Version,
+		Id:      item.Id,
+	}
+
+	result, err := v.call(ctx, wc, resolve, strings.ToLower(item.Method), item.Params)
+	switch {
+	case err != nil:
+		out.Error = errStrictConvert(err)
+	case result == nil:
+		out.Result = json.RawMessage("null")
+	default:
+		out.Result = result
+	}
+
+	if item.IsNotification() {
+		return nil
+	}
+
+	return out
+}
+
+func errStrictResponse(id json.RawMessage, code int64, message string) *responseStrict {
+	return &responseStrict{
+		Version: Version,
+		Id:      id,
+		Error: &errResponse{
+			Code:    code,
+			Message: message,
+		},
 	}
 }
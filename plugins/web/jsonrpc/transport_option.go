@@ -5,14 +5,22 @@
 
 package jsonrpc
 
-import "time"
+import (
+	"strings"
+	"time"
+)
 
 type Option func(o *options)
 
 type options struct {
-	timeout    time.Duration
-	path       string
-	errHandler func(method string, err error) error
+	timeout       time.Duration
+	path          string
+	errHandler    func(method string, err error) error
+	strict        bool
+	maxBatch      int
+	workers       int
+	methodTimeout map[string]time.Duration
+	observe       ObserveFunc
 }
 
 func Timeout(arg time.Duration) Option {
@@ -40,3 +48,36 @@ func ErrHandler(arg func(method string, err error) error) Option {
 		o.errHandler = arg
 	}
 }
+
+// StrictSpec switches the transport to the JSON-RPC 2.0 specification:
+// single and batch requests, notifications and standard error codes.
+func StrictSpec() Option {
+	return func(o *options) {
+		o.strict = true
+	}
+}
+
+// MaxBatchSize rejects batches with more requests than the limit, 0 disables the check
+func MaxBatchSize(arg int) Option {
+	return func(o *options) {
+		o.maxBatch = max(arg, 0)
+	}
+}
+
+// Workers limits how many requests of one batch are executed at the same time
+func Workers(arg int) Option {
+	return func(o *options) {
+		o.workers = max(arg, 1)
+	}
+}
+
+// MethodTimeout sets the handler timeout for a single method,
+// the request Timeout still limits the whole batch
+func MethodTimeout(method string, arg time.Duration) Option {
+	return func(o *options) {
+		if arg <= 0 {
+			return
+		}
+		o.methodTimeout[strings.ToLower(method)] = arg
+	}
+}
@@ -0,0 +1,169 @@
+/*
+ *  Copyright (c) 2022-2026 Mikhail Knyazhev <markus621@yandex.com>. All rights reserved.
+ *  Use of this source code is governed by a BSD 3-Clause license that can be found in the LICENSE file.
+ */
+
+package jsonrpc
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"go.osspkg.com/casecheck"
+	"go.osspkg.com/syncing"
+
+	"go.osspkg.com/goppy/v3/plugins/web"
+)
+
+func TestUnit_Transport_StrictSpec(t *testing.T) {
+	resolve := syncing.NewMap[string, THandleFunc](2)
+	resolve.Set("sum", func(_ context.Context, _ web.Ctx, p json.RawMessage) (any, error) {
+		var in []int
+		if err := json.Unmarshal(p, &in); err != nil {
+			return nil, ErrInvalidParams
+		}
+		sum := 0
+		for _, i := range in {
+			sum += i
+		}
+		return sum, nil
+	})
+	resolve.Set("fail", func(_ context.Context, _ web.Ctx, _ json.RawMessage) (any, error) {
+		return nil, fmt.Errorf("fail")
+	})
+
+	srv := newService(nil, StrictSpec()).(*service)
+	handler := srv.Handle(resolve)
+
+	tests := []struct {
+		name string
+		body string
+		code int
+		want string
+	}{
+		{
+			name: "single",
+			body: `{"jsonrpc":"2.0","method":"sum","params":[1,2,3],"id":1}`,
+			code: 200,
+			want: `{"jsonrpc":"2.0","id":1,"result":6}`,
+		},
+		{
+			name: "notification",
+			body: `{"jsonrpc":"2.0","method":"sum","params":[1,2,3]}`,
+			code: 204,
+			want: ``,
+		},
+		{
+			name: "parse error",
+			body: `{"jsonrpc":"2.0","method":"sum","params":[1,2`,
+			code: 200,
+			want: `{"jsonrpc":"2.0","id":null,"error":{"message":"Parse error","code":-32700}}`,
+		},
+		{
+			name: "empty batch",
+			body: `[]`,
+			code: 200,
+			want: `{"jsonrpc":"2.0","id":null,"error":{"message":"Invalid Request","code":-32600}}`,
+		},
+		{
+			name: "batch",
+			body: `[
+				{"jsonrpc":"2.0","method":"sum","params":[1,2],"id":"a"},
+				{"jsonrpc":"2.0","method":"sum","params":[1]},
+				{"jsonrpc":"2.0","method":"unknown","id":"b"},
+				{"jsonrpc":"2.0","method":"sum","params":{"a":1},"id":"c"},
+				{"jsonrpc":"2.0","method":"fail","id":"d"},
+				{"jsonrpc":"1.0","method":"sum","id":"e"},
+				1
+			]`,
+			code: 200,
+			want: `[` +
+				`{"jsonrpc":"2.0","id":"a","result":3},` +
+				`{"jsonrpc":"2.0","id":"b","error":{"message":"Method not found","code":-32601}},` +
+				`{"jsonrpc":"2.0","id":"c","error":{"message":"invalid params","code":-32602}},` +
+				`{"jsonrpc":"2.0","id":"d","error":{"message":"fail","code":-32603}},` +
+				`{"jsonrpc":"2.0","id":"e","error":{"message":"Invalid Request","code":-32600}},` +
+				`{"jsonrpc":"2.0","id":null,"error":{"message":"Invalid Request","code":-32600}}` +
+				`]`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(tt.body))
+			handler(web.NewCtx(w, r))
+
+			casecheck.Equal(t, tt.code, w.Code)
+			casecheck.Equal(t, tt.want, w.Body.String())
+		})
+	}
+}
+
+func TestUnit_Transport_Batch(t *testing.T) {
+	var active, peak atomic.Int64
+
+	resolve := syncing.NewMap[string, THandleFunc](2)
+	resolve.Set("echo", func(ctx context.Context, _ web.Ctx, p json.RawMessage) (any, error) {
+		cur := active.Add(1)
+		defer active.Add(-1)
+		for {
+			old := peak.Load()
+			if cur <= old || peak.CompareAndSwap(old, cur) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+		return p, nil
+	})
+	resolve.Set("panic", func(_ context.Context, _ web.Ctx, _ json.RawMessage) (any, error) {
+		panic("boom")
+	})
+	resolve.Set("slow", func(ctx context.Context, _ web.Ctx, _ json.RawMessage) (any, error) {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	})
+
+	srv := newService(nil,
+		Workers(2),
+		MaxBatchSize(10),
+		MethodTimeout("slow", 10*time.Millisecond),
+	).(*service)
+	handler := srv.Handle(resolve)
+
+	send := func(body string) (int, string) {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+		handler(web.NewCtx(w, r))
+		return w.Code, w.Body.String()
+	}
+
+	code, body := send(`[
+		{"id":"1","method":"echo","params":1},
+		{"id":"2","method":"panic"},
+		{"id":"3","method":"echo","params":3},
+		{"id":"4","method":"slow"},
+		{"id":"5","method":"echo","params":5},
+		{"id":"6","method":"echo","params":6}
+	]`)
+	casecheck.Equal(t, 200, code)
+	casecheck.Equal(t, `[`+
+		`{"id":"1","result":1},`+
+		`{"id":"2","error":{"message":"internal error","code":0}},`+
+		`{"id":"3","result":3},`+
+		`{"id":"4","error":{"message":"context deadline exceeded","code":0}},`+
+		`{"id":"5","result":5},`+
+		`{"id":"6","result":6}`+
+		`]`, body)
+	casecheck.True(t, peak.Load() <= 2)
+
+	code, body = send(`[` + strings.Repeat(`{"id":"1","method":"echo"},`, 10) + `{"id":"1","method":"echo"}]`)
+	casecheck.Equal(t, 400, code)
+	casecheck.Equal(t, ErrBatchSize.Error(), body)
+}
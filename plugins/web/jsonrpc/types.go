@@ -9,6 +9,7 @@ package jsonrpc
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 )
 
@@ -28,9 +29,10 @@ func (br *bulkRequestRaw) Reset() {
 
 //easyjson:json
 type requestAny struct {
-	Id     string `json:"id"`
-	Method string `json:"method"`
-	Params any    `json:"params"`
+	Version string `json:"jsonrpc,omitempty"`
+	Id      string `json:"id"`
+	Method  string `json:"method"`
+	Params  any    `json:"params"`
 }
 
 //easyjson:json
@@ -68,17 +70,71 @@ func (br *bulkResponseAny) Reset() {
 	*br = (*br)[:0]
 }
 
+// requestStrict is decoded with encoding/json, easyjson does not distinguish
+// an absent id (notification) from an explicit null id.
+type requestStrict struct {
+	Version string          `json:"jsonrpc"`
+	Id      json.RawMessage `json:"id"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params"`
+}
+
+func (r *requestStrict) IsNotification() bool {
+	return r.Id == nil
+}
+
+func (r *requestStrict) Validate() bool {
+	if r.Version != Version || len(r.Method) == 0 || !validStrictID(r.Id) {
+		return false
+	}
+	if len(r.Params) == 0 || string(r.Params) == "null" {
+		return true
+	}
+	return r.Params[0] == '{' || r.Params[0] == '['
+}
+
+func validStrictID(id json.RawMessage) bool {
+	if id == nil {
+		return true
+	}
+	switch id[0] {
+	case '"', 'n', '-', '0', '1', '2', '3', '4', '5', '6', '7', '8', '9':
+		return true
+	default:
+		return false
+	}
+}
+
+//easyjson:json
+type responseStrict struct {
+	Version string          `json:"jsonrpc"`
+	Id      json.RawMessage `json:"id"`
+	Result  any             `json:"result,omitempty"`
+	Error   *errResponse    `json:"error,omitempty"`
+}
+
+//easyjson:json
+type bulkResponseStrict []responseStrict
+
+func (br *bulkResponseStrict) Reset() {
+	*br = (*br)[:0]
+}
+
 //easyjson:json
 type errResponse struct {
 	Message string            `json:"message"`
 	Code    int64             `json:"code"`
 	Ctx     map[string]string `json:"ctx,omitempty"`
+	Data    map[string]string `json:"data,omitempty"`
 }
 
 func (e *errResponse) Error() string {
 	if e.Ctx != nil {
 		return fmt.Sprintf("#%d %v {%+v}", e.Code, e.Message, e.Ctx)
 	}
+	if e.Data != nil {
+		return fmt.Sprintf("#%d %v {%+v}", e.Code, e.Message, e.Data)
+	}
 	return fmt.Sprintf("#%d %v", e.Code, e.Message)
 }
 
@@ -97,3 +153,31 @@ func errConvert(e error) *errResponse {
 	}
 	return err
 }
+
+func errStrictConvert(e error) *errResponse {
+	if e == nil {
+		return nil
+	}
+	err := &errResponse{}
+	var (
+		te       TError
+		syntax   *json.SyntaxError
+		typeFail *json.UnmarshalTypeError
+	)
+	switch {
+	case errors.As(e, &te):
+		err.Code = te.GetCode()
+		err.Message = te.GetMessage()
+		err.Data = te.GetContext()
+	case errors.Is(e, ErrUnsupportedMethod):
+		err.Code = CodeMethodNotFound
+		err.Message = "Method not found"
+	case errors.Is(e, ErrInvalidParams), errors.As(e, &syntax), errors.As(e, &typeFail):
+		err.Code = CodeInvalidParams
+		err.Message = e.Error()
+	default:
+		err.Code = CodeInternalError
+		err.Message = e.Error()
+	}
+	return err
+}
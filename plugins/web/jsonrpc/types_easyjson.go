@@ -18,7 +18,123 @@ var (
 	_ easyjson.Marshaler
 )
 
-func easyjson6601e8cdDecodeGoOsspkgComGoppyV3PluginsWebJsonrpc(in *jlexer.Lexer, out *responseRaw) {
+func easyjson6601e8cdDecodeGoOsspkgComGoppyV3PluginsWebJsonrpc(in *jlexer.Lexer, out *responseStrict) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		switch key {
+		case "jsonrpc":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Version = string(in.String())
+			}
+		case "id":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				if data := in.Raw(); in.Ok() {
+					in.AddError((out.Id).UnmarshalJSON(data))
+				}
+			}
+		case "result":
+			if m, ok := out.Result.(easyjson.Unmarshaler); ok {
+				m.UnmarshalEasyJSON(in)
+			} else if m, ok := out.Result.(json.Unmarshaler); ok {
+				_ = m.UnmarshalJSON(in.Raw())
+			} else {
+				out.Result = in.Interface()
+			}
+		case "error":
+			if in.IsNull() {
+				in.Skip()
+				out.Error = nil
+			} else {
+				if out.Error == nil {
+					out.Error = new(errResponse)
+				}
+				if in.IsNull() {
+					in.Skip()
+				} else {
+					(*out.Error).UnmarshalEasyJSON(in)
+				}
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson6601e8cdEncodeGoOsspkgComGoppyV3PluginsWebJsonrpc(out *jwriter.Writer, in responseStrict) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"jsonrpc\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Version))
+	}
+	{
+		const prefix string = ",\"id\":"
+		out.RawString(prefix)
+		out.Raw((in.Id).MarshalJSON())
+	}
+	if in.Result != nil {
+		const prefix string = ",\"result\":"
+		out.RawString(prefix)
+		if m, ok := in.Result.(easyjson.Marshaler); ok {
+			m.MarshalEasyJSON(out)
+		} else if m, ok := in.Result.(json.Marshaler); ok {
+			out.Raw(m.MarshalJSON())
+		} else {
+			out.Raw(json.Marshal(in.Result))
+		}
+	}
+	if in.Error != nil {
+		const prefix string = ",\"error\":"
+		out.RawString(prefix)
+		(*in.Error).MarshalEasyJSON(out)
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v responseStrict) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson6601e8cdEncodeGoOsspkgComGoppyV3PluginsWebJsonrpc(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v responseStrict) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson6601e8cdEncodeGoOsspkgComGoppyV3PluginsWebJsonrpc(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *responseStrict) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson6601e8cdDecodeGoOsspkgComGoppyV3PluginsWebJsonrpc(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *responseStrict) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson6601e8cdDecodeGoOsspkgComGoppyV3PluginsWebJsonrpc(l, v)
+}
+func easyjson6601e8cdDecodeGoOsspkgComGoppyV3PluginsWebJsonrpc1(in *jlexer.Lexer, out *responseRaw) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -70,7 +186,7 @@ func easyjson6601e8cdDecodeGoOsspkgComGoppyV3PluginsWebJsonrpc(in *jlexer.Lexer,
 		in.Consumed()
 	}
 }
-func easyjson6601e8cdEncodeGoOsspkgComGoppyV3PluginsWebJsonrpc(out *jwriter.Writer, in responseRaw) {
+func easyjson6601e8cdEncodeGoOsspkgComGoppyV3PluginsWebJsonrpc1(out *jwriter.Writer, in responseRaw) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -95,27 +211,27 @@ func easyjson6601e8cdEncodeGoOsspkgComGoppyV3PluginsWebJsonrpc(out *jwriter.Writ
 // MarshalJSON supports json.Marshaler interface
 func (v responseRaw) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson6601e8cdEncodeGoOsspkgComGoppyV3PluginsWebJsonrpc(&w, v)
+	easyjson6601e8cdEncodeGoOsspkgComGoppyV3PluginsWebJsonrpc1(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v responseRaw) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson6601e8cdEncodeGoOsspkgComGoppyV3PluginsWebJsonrpc(w, v)
+	easyjson6601e8cdEncodeGoOsspkgComGoppyV3PluginsWebJsonrpc1(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *responseRaw) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson6601e8cdDecodeGoOsspkgComGoppyV3PluginsWebJsonrpc(&r, v)
+	easyjson6601e8cdDecodeGoOsspkgComGoppyV3PluginsWebJsonrpc1(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *responseRaw) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson6601e8cdDecodeGoOsspkgComGoppyV3PluginsWebJsonrpc(l, v)
+	easyjson6601e8cdDecodeGoOsspkgComGoppyV3PluginsWebJsonrpc1(l, v)
 }
-func easyjson6601e8cdDecodeGoOsspkgComGoppyV3PluginsWebJsonrpc1(in *jlexer.Lexer, out *responseAny) {
+func easyjson6601e8cdDecodeGoOsspkgComGoppyV3PluginsWebJsonrpc2(in *jlexer.Lexer, out *responseAny) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -167,7 +283,7 @@ func easyjson6601e8cdDecodeGoOsspkgComGoppyV3PluginsWebJsonrpc1(in *jlexer.Lexer
 		in.Consumed()
 	}
 }
-func easyjson6601e8cdEncodeGoOsspkgComGoppyV3PluginsWebJsonrpc1(out *jwriter.Writer, in responseAny) {
+func easyjson6601e8cdEncodeGoOsspkgComGoppyV3PluginsWebJsonrpc2(out *jwriter.Writer, in responseAny) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -198,27 +314,27 @@ func easyjson6601e8cdEncodeGoOsspkgComGoppyV3PluginsWebJsonrpc1(out *jwriter.Wri
 // MarshalJSON supports json.Marshaler interface
 func (v responseAny) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson6601e8cdEncodeGoOsspkgComGoppyV3PluginsWebJsonrpc1(&w, v)
+	easyjson6601e8cdEncodeGoOsspkgComGoppyV3PluginsWebJsonrpc2(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v responseAny) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson6601e8cdEncodeGoOsspkgComGoppyV3PluginsWebJsonrpc1(w, v)
+	easyjson6601e8cdEncodeGoOsspkgComGoppyV3PluginsWebJsonrpc2(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *responseAny) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson6601e8cdDecodeGoOsspkgComGoppyV3PluginsWebJsonrpc1(&r, v)
+	easyjson6601e8cdDecodeGoOsspkgComGoppyV3PluginsWebJsonrpc2(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *responseAny) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson6601e8cdDecodeGoOsspkgComGoppyV3PluginsWebJsonrpc1(l, v)
+	easyjson6601e8cdDecodeGoOsspkgComGoppyV3PluginsWebJsonrpc2(l, v)
 }
-func easyjson6601e8cdDecodeGoOsspkgComGoppyV3PluginsWebJsonrpc2(in *jlexer.Lexer, out *requestRaw) {
+func easyjson6601e8cdDecodeGoOsspkgComGoppyV3PluginsWebJsonrpc3(in *jlexer.Lexer, out *requestRaw) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -262,7 +378,7 @@ func easyjson6601e8cdDecodeGoOsspkgComGoppyV3PluginsWebJsonrpc2(in *jlexer.Lexer
 		in.Consumed()
 	}
 }
-func easyjson6601e8cdEncodeGoOsspkgComGoppyV3PluginsWebJsonrpc2(out *jwriter.Writer, in requestRaw) {
+func easyjson6601e8cdEncodeGoOsspkgComGoppyV3PluginsWebJsonrpc3(out *jwriter.Writer, in requestRaw) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -287,27 +403,27 @@ func easyjson6601e8cdEncodeGoOsspkgComGoppyV3PluginsWebJsonrpc2(out *jwriter.Wri
 // MarshalJSON supports json.Marshaler interface
 func (v requestRaw) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson6601e8cdEncodeGoOsspkgComGoppyV3PluginsWebJsonrpc2(&w, v)
+	easyjson6601e8cdEncodeGoOsspkgComGoppyV3PluginsWebJsonrpc3(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v requestRaw) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson6601e8cdEncodeGoOsspkgComGoppyV3PluginsWebJsonrpc2(w, v)
+	easyjson6601e8cdEncodeGoOsspkgComGoppyV3PluginsWebJsonrpc3(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *requestRaw) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson6601e8cdDecodeGoOsspkgComGoppyV3PluginsWebJsonrpc2(&r, v)
+	easyjson6601e8cdDecodeGoOsspkgComGoppyV3PluginsWebJsonrpc3(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *requestRaw) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson6601e8cdDecodeGoOsspkgComGoppyV3PluginsWebJsonrpc2(l, v)
+	easyjson6601e8cdDecodeGoOsspkgComGoppyV3PluginsWebJsonrpc3(l, v)
 }
-func easyjson6601e8cdDecodeGoOsspkgComGoppyV3PluginsWebJsonrpc3(in *jlexer.Lexer, out *requestAny) {
+func easyjson6601e8cdDecodeGoOsspkgComGoppyV3PluginsWebJsonrpc4(in *jlexer.Lexer, out *requestAny) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -321,6 +437,12 @@ func easyjson6601e8cdDecodeGoOsspkgComGoppyV3PluginsWebJsonrpc3(in *jlexer.Lexer
 		key := in.UnsafeFieldName(false)
 		in.WantColon()
 		switch key {
+		case "jsonrpc":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Version = string(in.String())
+			}
 		case "id":
 			if in.IsNull() {
 				in.Skip()
@@ -351,13 +473,24 @@ func easyjson6601e8cdDecodeGoOsspkgComGoppyV3PluginsWebJsonrpc3(in *jlexer.Lexer
 		in.Consumed()
 	}
 }
-func easyjson6601e8cdEncodeGoOsspkgComGoppyV3PluginsWebJsonrpc3(out *jwriter.Writer, in requestAny) {
+func easyjson6601e8cdEncodeGoOsspkgComGoppyV3PluginsWebJsonrpc4(out *jwriter.Writer, in requestAny) {
 	out.RawByte('{')
 	first := true
 	_ = first
+	if in.Version != "" {
+		const prefix string = ",\"jsonrpc\":"
+		first = false
+		out.RawString(prefix[1:])
+		out.String(string(in.Version))
+	}
 	{
 		const prefix string = ",\"id\":"
-		out.RawString(prefix[1:])
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
 		out.String(string(in.Id))
 	}
 	{
@@ -382,27 +515,27 @@ func easyjson6601e8cdEncodeGoOsspkgComGoppyV3PluginsWebJsonrpc3(out *jwriter.Wri
 // MarshalJSON supports json.Marshaler interface
 func (v requestAny) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson6601e8cdEncodeGoOsspkgComGoppyV3PluginsWebJsonrpc3(&w, v)
+	easyjson6601e8cdEncodeGoOsspkgComGoppyV3PluginsWebJsonrpc4(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v requestAny) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson6601e8cdEncodeGoOsspkgComGoppyV3PluginsWebJsonrpc3(w, v)
+	easyjson6601e8cdEncodeGoOsspkgComGoppyV3PluginsWebJsonrpc4(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *requestAny) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson6601e8cdDecodeGoOsspkgComGoppyV3PluginsWebJsonrpc3(&r, v)
+	easyjson6601e8cdDecodeGoOsspkgComGoppyV3PluginsWebJsonrpc4(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *requestAny) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson6601e8cdDecodeGoOsspkgComGoppyV3PluginsWebJsonrpc3(l, v)
+	easyjson6601e8cdDecodeGoOsspkgComGoppyV3PluginsWebJsonrpc4(l, v)
 }
-func easyjson6601e8cdDecodeGoOsspkgComGoppyV3PluginsWebJsonrpc4(in *jlexer.Lexer, out *errResponse) {
+func easyjson6601e8cdDecodeGoOsspkgComGoppyV3PluginsWebJsonrpc5(in *jlexer.Lexer, out *errResponse) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -452,6 +585,30 @@ func easyjson6601e8cdDecodeGoOsspkgComGoppyV3PluginsWebJsonrpc4(in *jlexer.Lexer
 				}
 				in.Delim('}')
 			}
+		case "data":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				in.Delim('{')
+				if !in.IsDelim('}') {
+					out.Data = make(map[string]string)
+				} else {
+					out.Data = nil
+				}
+				for !in.IsDelim('}') {
+					key := string(in.String())
+					in.WantColon()
+					var v2 string
+					if in.IsNull() {
+						in.Skip()
+					} else {
+						v2 = string(in.String())
+					}
+					(out.Data)[key] = v2
+					in.WantComma()
+				}
+				in.Delim('}')
+			}
 		default:
 			in.SkipRecursive()
 		}
@@ -462,7 +619,7 @@ func easyjson6601e8cdDecodeGoOsspkgComGoppyV3PluginsWebJsonrpc4(in *jlexer.Lexer
 		in.Consumed()
 	}
 }
-func easyjson6601e8cdEncodeGoOsspkgComGoppyV3PluginsWebJsonrpc4(out *jwriter.Writer, in errResponse) {
+func easyjson6601e8cdEncodeGoOsspkgComGoppyV3PluginsWebJsonrpc5(out *jwriter.Writer, in errResponse) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -481,16 +638,35 @@ func easyjson6601e8cdEncodeGoOsspkgComGoppyV3PluginsWebJsonrpc4(out *jwriter.Wri
 		out.RawString(prefix)
 		{
 			out.RawByte('{')
-			v2First := true
-			for v2Name, v2Value := range in.Ctx {
-				if v2First {
-					v2First = false
+			v3First := true
+			for v3Name, v3Value := range in.Ctx {
+				if v3First {
+					v3First = false
+				} else {
+					out.RawByte(',')
+				}
+				out.String(string(v3Name))
+				out.RawByte(':')
+				out.String(string(v3Value))
+			}
+			out.RawByte('}')
+		}
+	}
+	if len(in.Data) != 0 {
+		const prefix string = ",\"data\":"
+		out.RawString(prefix)
+		{
+			out.RawByte('{')
+			v4First := true
+			for v4Name, v4Value := range in.Data {
+				if v4First {
+					v4First = false
 				} else {
 					out.RawByte(',')
 				}
-				out.String(string(v2Name))
+				out.String(string(v4Name))
 				out.RawByte(':')
-				out.String(string(v2Value))
+				out.String(string(v4Value))
 			}
 			out.RawByte('}')
 		}
@@ -501,27 +677,97 @@ func easyjson6601e8cdEncodeGoOsspkgComGoppyV3PluginsWebJsonrpc4(out *jwriter.Wri
 // MarshalJSON supports json.Marshaler interface
 func (v errResponse) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson6601e8cdEncodeGoOsspkgComGoppyV3PluginsWebJsonrpc4(&w, v)
+	easyjson6601e8cdEncodeGoOsspkgComGoppyV3PluginsWebJsonrpc5(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v errResponse) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson6601e8cdEncodeGoOsspkgComGoppyV3PluginsWebJsonrpc4(w, v)
+	easyjson6601e8cdEncodeGoOsspkgComGoppyV3PluginsWebJsonrpc5(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *errResponse) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson6601e8cdDecodeGoOsspkgComGoppyV3PluginsWebJsonrpc4(&r, v)
+	easyjson6601e8cdDecodeGoOsspkgComGoppyV3PluginsWebJsonrpc5(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *errResponse) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson6601e8cdDecodeGoOsspkgComGoppyV3PluginsWebJsonrpc4(l, v)
+	easyjson6601e8cdDecodeGoOsspkgComGoppyV3PluginsWebJsonrpc5(l, v)
 }
-func easyjson6601e8cdDecodeGoOsspkgComGoppyV3PluginsWebJsonrpc5(in *jlexer.Lexer, out *bulkResponseRaw) {
+func easyjson6601e8cdDecodeGoOsspkgComGoppyV3PluginsWebJsonrpc6(in *jlexer.Lexer, out *bulkResponseStrict) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		in.Skip()
+		*out = nil
+	} else {
+		in.Delim('[')
+		if *out == nil {
+			if !in.IsDelim(']') {
+				*out = make(bulkResponseStrict, 0, 1)
+			} else {
+				*out = bulkResponseStrict{}
+			}
+		} else {
+			*out = (*out)[:0]
+		}
+		for !in.IsDelim(']') {
+			var v5 responseStrict
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				(v5).UnmarshalEasyJSON(in)
+			}
+			*out = append(*out, v5)
+			in.WantComma()
+		}
+		in.Delim(']')
+	}
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson6601e8cdEncodeGoOsspkgComGoppyV3PluginsWebJsonrpc6(out *jwriter.Writer, in bulkResponseStrict) {
+	if in == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+		out.RawString("null")
+	} else {
+		out.RawByte('[')
+		for v6, v7 := range in {
+			if v6 > 0 {
+				out.RawByte(',')
+			}
+			(v7).MarshalEasyJSON(out)
+		}
+		out.RawByte(']')
+	}
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v bulkResponseStrict) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson6601e8cdEncodeGoOsspkgComGoppyV3PluginsWebJsonrpc6(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v bulkResponseStrict) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson6601e8cdEncodeGoOsspkgComGoppyV3PluginsWebJsonrpc6(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *bulkResponseStrict) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson6601e8cdDecodeGoOsspkgComGoppyV3PluginsWebJsonrpc6(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *bulkResponseStrict) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson6601e8cdDecodeGoOsspkgComGoppyV3PluginsWebJsonrpc6(l, v)
+}
+func easyjson6601e8cdDecodeGoOsspkgComGoppyV3PluginsWebJsonrpc7(in *jlexer.Lexer, out *bulkResponseRaw) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		in.Skip()
@@ -538,13 +784,13 @@ func easyjson6601e8cdDecodeGoOsspkgComGoppyV3PluginsWebJsonrpc5(in *jlexer.Lexer
 			*out = (*out)[:0]
 		}
 		for !in.IsDelim(']') {
-			var v3 responseRaw
+			var v8 responseRaw
 			if in.IsNull() {
 				in.Skip()
 			} else {
-				(v3).UnmarshalEasyJSON(in)
+				(v8).UnmarshalEasyJSON(in)
 			}
-			*out = append(*out, v3)
+			*out = append(*out, v8)
 			in.WantComma()
 		}
 		in.Delim(']')
@@ -553,16 +799,16 @@ func easyjson6601e8cdDecodeGoOsspkgComGoppyV3PluginsWebJsonrpc5(in *jlexer.Lexer
 		in.Consumed()
 	}
 }
-func easyjson6601e8cdEncodeGoOsspkgComGoppyV3PluginsWebJsonrpc5(out *jwriter.Writer, in bulkResponseRaw) {
+func easyjson6601e8cdEncodeGoOsspkgComGoppyV3PluginsWebJsonrpc7(out *jwriter.Writer, in bulkResponseRaw) {
 	if in == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
 		out.RawString("null")
 	} else {
 		out.RawByte('[')
-		for v4, v5 := range in {
-			if v4 > 0 {
+		for v9, v10 := range in {
+			if v9 > 0 {
 				out.RawByte(',')
 			}
-			(v5).MarshalEasyJSON(out)
+			(v10).MarshalEasyJSON(out)
 		}
 		out.RawByte(']')
 	}
@@ -571,27 +817,27 @@ func easyjson6601e8cdEncodeGoOsspkgComGoppyV3PluginsWebJsonrpc5(out *jwriter.Wri
 // MarshalJSON supports json.Marshaler interface
 func (v bulkResponseRaw) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson6601e8cdEncodeGoOsspkgComGoppyV3PluginsWebJsonrpc5(&w, v)
+	easyjson6601e8cdEncodeGoOsspkgComGoppyV3PluginsWebJsonrpc7(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v bulkResponseRaw) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson6601e8cdEncodeGoOsspkgComGoppyV3PluginsWebJsonrpc5(w, v)
+	easyjson6601e8cdEncodeGoOsspkgComGoppyV3PluginsWebJsonrpc7(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *bulkResponseRaw) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson6601e8cdDecodeGoOsspkgComGoppyV3PluginsWebJsonrpc5(&r, v)
+	easyjson6601e8cdDecodeGoOsspkgComGoppyV3PluginsWebJsonrpc7(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *bulkResponseRaw) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson6601e8cdDecodeGoOsspkgComGoppyV3PluginsWebJsonrpc5(l, v)
+	easyjson6601e8cdDecodeGoOsspkgComGoppyV3PluginsWebJsonrpc7(l, v)
 }
-func easyjson6601e8cdDecodeGoOsspkgComGoppyV3PluginsWebJsonrpc6(in *jlexer.Lexer, out *bulkResponseAny) {
+func easyjson6601e8cdDecodeGoOsspkgComGoppyV3PluginsWebJsonrpc8(in *jlexer.Lexer, out *bulkResponseAny) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		in.Skip()
@@ -608,13 +854,13 @@ func easyjson6601e8cdDecodeGoOsspkgComGoppyV3PluginsWebJsonrpc6(in *jlexer.Lexer
 			*out = (*out)[:0]
 		}
 		for !in.IsDelim(']') {
-			var v6 responseAny
+			var v11 responseAny
 			if in.IsNull() {
 				in.Skip()
 			} else {
-				(v6).UnmarshalEasyJSON(in)
+				(v11).UnmarshalEasyJSON(in)
 			}
-			*out = append(*out, v6)
+			*out = append(*out, v11)
 			in.WantComma()
 		}
 		in.Delim(']')
@@ -623,16 +869,16 @@ func easyjson6601e8cdDecodeGoOsspkgComGoppyV3PluginsWebJsonrpc6(in *jlexer.Lexer
 		in.Consumed()
 	}
 }
-func easyjson6601e8cdEncodeGoOsspkgComGoppyV3PluginsWebJsonrpc6(out *jwriter.Writer, in bulkResponseAny) {
+func easyjson6601e8cdEncodeGoOsspkgComGoppyV3PluginsWebJsonrpc8(out *jwriter.Writer, in bulkResponseAny) {
 	if in == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
 		out.RawString("null")
 	} else {
 		out.RawByte('[')
-		for v7, v8 := range in {
-			if v7 > 0 {
+		for v12, v13 := range in {
+			if v12 > 0 {
 				out.RawByte(',')
 			}
-			(v8).MarshalEasyJSON(out)
+			(v13).MarshalEasyJSON(out)
 		}
 		out.RawByte(']')
 	}
@@ -641,27 +887,27 @@ func easyjson6601e8cdEncodeGoOsspkgComGoppyV3PluginsWebJsonrpc6(out *jwriter.Wri
 // MarshalJSON supports json.Marshaler interface
 func (v bulkResponseAny) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson6601e8cdEncodeGoOsspkgComGoppyV3PluginsWebJsonrpc6(&w, v)
+	easyjson6601e8cdEncodeGoOsspkgComGoppyV3PluginsWebJsonrpc8(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v bulkResponseAny) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson6601e8cdEncodeGoOsspkgComGoppyV3PluginsWebJsonrpc6(w, v)
+	easyjson6601e8cdEncodeGoOsspkgComGoppyV3PluginsWebJsonrpc8(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *bulkResponseAny) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson6601e8cdDecodeGoOsspkgComGoppyV3PluginsWebJsonrpc6(&r, v)
+	easyjson6601e8cdDecodeGoOsspkgComGoppyV3PluginsWebJsonrpc8(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *bulkResponseAny) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson6601e8cdDecodeGoOsspkgComGoppyV3PluginsWebJsonrpc6(l, v)
+	easyjson6601e8cdDecodeGoOsspkgComGoppyV3PluginsWebJsonrpc8(l, v)
 }
-func easyjson6601e8cdDecodeGoOsspkgComGoppyV3PluginsWebJsonrpc7(in *jlexer.Lexer, out *bulkRequestRaw) {
+func easyjson6601e8cdDecodeGoOsspkgComGoppyV3PluginsWebJsonrpc9(in *jlexer.Lexer, out *bulkRequestRaw) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		in.Skip()
@@ -678,13 +924,13 @@ func easyjson6601e8cdDecodeGoOsspkgComGoppyV3PluginsWebJsonrpc7(in *jlexer.Lexer
 			*out = (*out)[:0]
 		}
 		for !in.IsDelim(']') {
-			var v9 requestRaw
+			var v14 requestRaw
 			if in.IsNull() {
 				in.Skip()
 			} else {
-				(v9).UnmarshalEasyJSON(in)
+				(v14).UnmarshalEasyJSON(in)
 			}
-			*out = append(*out, v9)
+			*out = append(*out, v14)
 			in.WantComma()
 		}
 		in.Delim(']')
@@ -693,16 +939,16 @@ func easyjson6601e8cdDecodeGoOsspkgComGoppyV3PluginsWebJsonrpc7(in *jlexer.Lexer
 		in.Consumed()
 	}
 }
-func easyjson6601e8cdEncodeGoOsspkgComGoppyV3PluginsWebJsonrpc7(out *jwriter.Writer, in bulkRequestRaw) {
+func easyjson6601e8cdEncodeGoOsspkgComGoppyV3PluginsWebJsonrpc9(out *jwriter.Writer, in bulkRequestRaw) {
 	if in == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
 		out.RawString("null")
 	} else {
 		out.RawByte('[')
-		for v10, v11 := range in {
-			if v10 > 0 {
+		for v15, v16 := range in {
+			if v15 > 0 {
 				out.RawByte(',')
 			}
-			(v11).MarshalEasyJSON(out)
+			(v16).MarshalEasyJSON(out)
 		}
 		out.RawByte(']')
 	}
@@ -711,27 +957,27 @@ func easyjson6601e8cdEncodeGoOsspkgComGoppyV3PluginsWebJsonrpc7(out *jwriter.Wri
 // MarshalJSON supports json.Marshaler interface
 func (v bulkRequestRaw) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson6601e8cdEncodeGoOsspkgComGoppyV3PluginsWebJsonrpc7(&w, v)
+	easyjson6601e8cdEncodeGoOsspkgComGoppyV3PluginsWebJsonrpc9(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v bulkRequestRaw) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson6601e8cdEncodeGoOsspkgComGoppyV3PluginsWebJsonrpc7(w, v)
+	easyjson6601e8cdEncodeGoOsspkgComGoppyV3PluginsWebJsonrpc9(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *bulkRequestRaw) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson6601e8cdDecodeGoOsspkgComGoppyV3PluginsWebJsonrpc7(&r, v)
+	easyjson6601e8cdDecodeGoOsspkgComGoppyV3PluginsWebJsonrpc9(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *bulkRequestRaw) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson6601e8cdDecodeGoOsspkgComGoppyV3PluginsWebJsonrpc7(l, v)
+	easyjson6601e8cdDecodeGoOsspkgComGoppyV3PluginsWebJsonrpc9(l, v)
 }
-func easyjson6601e8cdDecodeGoOsspkgComGoppyV3PluginsWebJsonrpc8(in *jlexer.Lexer, out *bulkRequestAny) {
+func easyjson6601e8cdDecodeGoOsspkgComGoppyV3PluginsWebJsonrpc10(in *jlexer.Lexer, out *bulkRequestAny) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		in.Skip()
@@ -748,13 +994,13 @@ func easyjson6601e8cdDecodeGoOsspkgComGoppyV3PluginsWebJsonrpc8(in *jlexer.Lexer
 			*out = (*out)[:0]
 		}
 		for !in.IsDelim(']') {
-			var v12 requestAny
+			var v17 requestAny
 			if in.IsNull() {
 				in.Skip()
 			} else {
-				(v12).UnmarshalEasyJSON(in)
+				(v17).UnmarshalEasyJSON(in)
 			}
-			*out = append(*out, v12)
+			*out = append(*out, v17)
 			in.WantComma()
 		}
 		in.Delim(']')
@@ -763,16 +1009,16 @@ func easyjson6601e8cdDecodeGoOsspkgComGoppyV3PluginsWebJsonrpc8(in *jlexer.Lexer
 		in.Consumed()
 	}
 }
-func easyjson6601e8cdEncodeGoOsspkgComGoppyV3PluginsWebJsonrpc8(out *jwriter.Writer, in bulkRequestAny) {
+func easyjson6601e8cdEncodeGoOsspkgComGoppyV3PluginsWebJsonrpc10(out *jwriter.Writer, in bulkRequestAny) {
 	if in == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
 		out.RawString("null")
 	} else {
 		out.RawByte('[')
-		for v13, v14 := range in {
-			if v13 > 0 {
+		for v18, v19 := range in {
+			if v18 > 0 {
 				out.RawByte(',')
 			}
-			(v14).MarshalEasyJSON(out)
+			(v19).MarshalEasyJSON(out)
 		}
 		out.RawByte(']')
 	}
@@ -781,23 +1027,23 @@ func easyjson6601e8cdEncodeGoOsspkgComGoppyV3PluginsWebJsonrpc8(out *jwriter.Wri
 // MarshalJSON supports json.Marshaler interface
 func (v bulkRequestAny) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson6601e8cdEncodeGoOsspkgComGoppyV3PluginsWebJsonrpc8(&w, v)
+	easyjson6601e8cdEncodeGoOsspkgComGoppyV3PluginsWebJsonrpc10(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v bulkRequestAny) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson6601e8cdEncodeGoOsspkgComGoppyV3PluginsWebJsonrpc8(w, v)
+	easyjson6601e8cdEncodeGoOsspkgComGoppyV3PluginsWebJsonrpc10(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *bulkRequestAny) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson6601e8cdDecodeGoOsspkgComGoppyV3PluginsWebJsonrpc8(&r, v)
+	easyjson6601e8cdDecodeGoOsspkgComGoppyV3PluginsWebJsonrpc10(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *bulkRequestAny) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson6601e8cdDecodeGoOsspkgComGoppyV3PluginsWebJsonrpc8(l, v)
+	easyjson6601e8cdDecodeGoOsspkgComGoppyV3PluginsWebJsonrpc10(l, v)
 }
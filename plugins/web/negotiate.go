@@ -0,0 +1,85 @@
+/*
+ *  Copyright (c) 2022-2026 Mikhail Knyazhev <markus621@yandex.com>. All rights reserved.
+ *  Use of this source code is governed by a BSD 3-Clause license that can be found in the LICENSE file.
+ */
+
+package web
+
+import (
+	"strconv"
+	"strings"
+)
+
+type acceptRange struct {
+	value string
+	q     float64
+}
+
+// parseAccept parses values of the Accept or Accept-Encoding header
+func parseAccept(values []string) []acceptRange {
+	var result []acceptRange
+	for _, value := range values {
+		for _, item := range strings.Split(value, ",") {
+			item = strings.TrimSpace(item)
+			if len(item) == 0 {
+				continue
+			}
+			r := acceptRange{q: 1}
+			parts := strings.Split(item, ";")
+			r.value = strings.ToLower(strings.TrimSpace(parts[0]))
+			for _, param := range parts[1:] {
+				key, val, ok := strings.Cut(strings.TrimSpace(param), "=")
+				if !ok || !strings.EqualFold(key, "q") {
+					continue
+				}
+				if q, err := strconv.ParseFloat(val, 64); err == nil {
+					r.q = q
+				}
+			}
+			result = append(result, r)
+		}
+	}
+	return result
+}
+
+// negotiate selects the offer with the highest quality, the quality of the offer is taken from
+// the most specific matched range (`type/subtype`, `type/*`, `*/*` or `*`), the first offer wins on ties
+// and is returned if the header is empty, empty string is returned if nothing is acceptable
+func negotiate(values []string, offers ...string) string {
+	ranges := parseAccept(values)
+	if len(ranges) == 0 {
+		if len(offers) > 0 {
+			return offers[0]
+		}
+		return ""
+	}
+
+	best, bestQ := "", 0.0
+	for _, offer := range offers {
+		q, specificity := 0.0, -1
+		for _, r := range ranges {
+			if s := acceptMatch(r.value, strings.ToLower(offer)); s > specificity {
+				q, specificity = r.q, s
+			}
+		}
+		if q > bestQ {
+			best, bestQ = offer, q
+		}
+	}
+	return best
+}
+
+func acceptMatch(pattern, offer string) int {
+	switch {
+	case pattern == offer:
+		return 3
+	case pattern == "*" || pattern == "*/*":
+		return 1
+	case strings.HasSuffix(pattern, "/*"):
+		if strings.HasPrefix(offer, pattern[:len(pattern)-1]) {
+			return 2
+		}
+	default:
+	}
+	return -1
+}
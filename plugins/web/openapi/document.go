@@ -0,0 +1,87 @@
+/*
+ *  Copyright (c) 2022-2026 Mikhail Knyazhev <markus621@yandex.com>. All rights reserved.
+ *  Use of this source code is governed by a BSD 3-Clause license that can be found in the LICENSE file.
+ */
+
+package openapi
+
+// Version of the OpenAPI specification of the generated document
+const Version = "3.1.0"
+
+type (
+	// Document root object of the OpenAPI document
+	Document struct {
+		OpenAPI    string              `json:"openapi"`
+		Info       Info                `json:"info"`
+		Paths      map[string]PathItem `json:"paths"`
+		Components *Components         `json:"components,omitempty"`
+	}
+
+	// Info metadata about the API
+	Info struct {
+		Title   string `json:"title"`
+		Version string `json:"version"`
+	}
+
+	// PathItem operations available on a single path, the key is a method in lower case
+	PathItem map[string]*Operation
+
+	// Operation single API operation on a path
+	Operation struct {
+		OperationID string               `json:"operationId,omitempty"`
+		Summary     string               `json:"summary,omitempty"`
+		Parameters  []Parameter          `json:"parameters,omitempty"`
+		RequestBody *RequestBody         `json:"requestBody,omitempty"`
+		Responses   map[string]*Response `json:"responses"`
+	}
+
+	// Parameter single operation parameter
+	Parameter struct {
+		Name     string  `json:"name"`
+		In       string  `json:"in"`
+		Required bool    `json:"required,omitempty"`
+		Schema   *Schema `json:"schema,omitempty"`
+	}
+
+	// RequestBody request body of the operation
+	RequestBody struct {
+		Required bool                 `json:"required,omitempty"`
+		Content  map[string]MediaType `json:"content"`
+	}
+
+	// Response single response of the operation
+	Response struct {
+		Description string               `json:"description"`
+		Content     map[string]MediaType `json:"content,omitempty"`
+	}
+
+	// MediaType schema of the content
+	MediaType struct {
+		Schema *Schema `json:"schema,omitempty"`
+	}
+
+	// Components reusable objects of the document
+	Components struct {
+		Schemas map[string]*Schema `json:"schemas,omitempty"`
+	}
+
+	// Schema JSON Schema of the data
+	Schema struct {
+		Ref                  string             `json:"$ref,omitempty"`
+		Type                 any                `json:"type,omitempty"`
+		Format               string             `json:"format,omitempty"`
+		Pattern              string             `json:"pattern,omitempty"`
+		Const                any                `json:"const,omitempty"`
+		Items                *Schema            `json:"items,omitempty"`
+		Properties           map[string]*Schema `json:"properties,omitempty"`
+		Required             []string           `json:"required,omitempty"`
+		AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+		OneOf                []*Schema          `json:"oneOf,omitempty"`
+	}
+)
+
+const (
+	mimeJSON = "application/json"
+
+	componentsPrefix = "#/components/schemas/"
+)
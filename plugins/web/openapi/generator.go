@@ -0,0 +1,238 @@
+/*
+ *  Copyright (c) 2022-2026 Mikhail Knyazhev <markus621@yandex.com>. All rights reserved.
+ *  Use of this source code is governed by a BSD 3-Clause license that can be found in the LICENSE file.
+ */
+
+package openapi
+
+import (
+	"net/http"
+	"regexp"
+	"slices"
+	"strings"
+
+	"go.osspkg.com/goppy/v3/plugins/web"
+	"go.osspkg.com/goppy/v3/plugins/web/jsonrpc"
+)
+
+var rexPathParams = regexp.MustCompile(`\{([a-z0-9]+)\:?([^{}]*)\}`)
+
+const anyPath = "#"
+
+// Generator builder of the OpenAPI document
+type Generator struct {
+	doc     *Document
+	schemas *schemas
+}
+
+// NewGenerator creates an empty document with the API title and version
+func NewGenerator(title, version string) *Generator {
+	return &Generator{
+		doc: &Document{
+			OpenAPI: Version,
+			Info:    Info{Title: title, Version: version},
+			Paths:   make(map[string]PathItem, 10),
+		},
+		schemas: newSchemas(),
+	}
+}
+
+// AddRoutes adds operations for the routes of the web router
+func (v *Generator) AddRoutes(routes []web.RouteInfo) {
+	for _, r := range routes {
+		v.AddOperation(r.Method, r.Path, nil, nil)
+	}
+}
+
+// AddOperation adds the operation with request and response models,
+// if the model is nil then the body is not described
+func (v *Generator) AddOperation(method, uri string, req, res any) {
+	method = strings.ToLower(method)
+	uri, params := convertPath(uri)
+
+	op := &Operation{
+		OperationID: operationID(method, uri),
+		Parameters:  params,
+		Responses: map[string]*Response{
+			"default": {Description: "default response"},
+		},
+	}
+	if req != nil {
+		op.RequestBody = &RequestBody{
+			Required: true,
+			Content:  map[string]MediaType{mimeJSON: {Schema: v.schemas.Of(req)}},
+		}
+	}
+	if res != nil {
+		op.Responses = map[string]*Response{
+			"200": {
+				Description: http.StatusText(http.StatusOK),
+				Content:     map[string]MediaType{mimeJSON: {Schema: v.schemas.Of(res)}},
+			},
+		}
+	}
+
+	v.setOperation(uri, method, op)
+}
+
+// AddJSONRPC adds the JSON-RPC endpoint with the request and response models of the methods
+func (v *Generator) AddJSONRPC(d jsonrpc.TDescription) {
+	if len(d.Models) == 0 {
+		return
+	}
+
+	methods := make([]string, 0, len(d.Models))
+	for method := range d.Models {
+		methods = append(methods, method)
+	}
+	slices.Sort(methods)
+
+	errSchema := v.jsonrpcError()
+
+	var reqItems, resItems []*Schema
+	for _, method := range methods {
+		model := d.Models[method]
+
+		params := &Schema{}
+		if model.Request != nil {
+			params = v.schemas.Named(method+".request", model.Request)
+		}
+		result := &Schema{}
+		if model.Response != nil {
+			result = v.schemas.Named(method+".response", model.Response)
+		}
+
+		req := &Schema{
+			Type: "object",
+			Properties: map[string]*Schema{
+				"id":     jsonrpcID(d.Strict),
+				"method": {Type: "string", Const: method},
+				"params": params,
+			},
+			Required: []string{"id", "method"},
+		}
+		res := &Schema{
+			Type: "object",
+			Properties: map[string]*Schema{
+				"id":     jsonrpcID(d.Strict),
+				"result": result,
+				"error":  errSchema,
+			},
+			Required: []string{"id"},
+		}
+		if d.Strict {
+			req.Properties["jsonrpc"] = &Schema{Type: "string", Const: jsonrpc.Version}
+			req.Required = []string{"jsonrpc", "method"}
+			res.Properties["jsonrpc"] = &Schema{Type: "string", Const: jsonrpc.Version}
+			res.Required = []string{"jsonrpc", "id"}
+		}
+
+		reqItems = append(reqItems, req)
+		resItems = append(resItems, res)
+	}
+
+	reqSchema := &Schema{Type: "array", Items: &Schema{OneOf: reqItems}}
+	resSchema := &Schema{Type: "array", Items: &Schema{OneOf: resItems}}
+	if d.Strict {
+		reqSchema = &Schema{OneOf: []*Schema{reqSchema.Items, reqSchema}}
+		resSchema = &Schema{OneOf: []*Schema{resSchema.Items, resSchema}}
+	}
+
+	uri, _ := convertPath(d.Path)
+	v.setOperation(uri, "post", &Operation{
+		OperationID: operationID("jsonrpc", uri),
+		Summary:     "JSON-RPC methods: " + strings.Join(methods, ", "),
+		RequestBody: &RequestBody{
+			Required: true,
+			Content:  map[string]MediaType{mimeJSON: {Schema: reqSchema}},
+		},
+		Responses: map[string]*Response{
+			"200": {
+				Description: http.StatusText(http.StatusOK),
+				Content:     map[string]MediaType{mimeJSON: {Schema: resSchema}},
+			},
+		},
+	})
+}
+
+// Document returns the built document
+func (v *Generator) Document() *Document {
+	if items := v.schemas.Components(); len(items) > 0 {
+		v.doc.Components = &Components{Schemas: items}
+	}
+	return v.doc
+}
+
+func (v *Generator) setOperation(uri, method string, op *Operation) {
+	item, ok := v.doc.Paths[uri]
+	if !ok {
+		item = make(PathItem, 2)
+		v.doc.Paths[uri] = item
+	}
+	item[method] = op
+}
+
+func (v *Generator) jsonrpcError() *Schema {
+	const name = "jsonrpc.error"
+	if _, ok := v.schemas.items[name]; !ok {
+		v.schemas.items[name] = &Schema{
+			Type: "object",
+			Properties: map[string]*Schema{
+				"message": {Type: "string"},
+				"code":    {Type: "integer", Format: "int64"},
+				"ctx":     {Type: "object", AdditionalProperties: &Schema{Type: "string"}},
+				"data":    {Type: "object", AdditionalProperties: &Schema{Type: "string"}},
+			},
+			Required: []string{"message", "code"},
+		}
+	}
+	return &Schema{Ref: componentsPrefix + name}
+}
+
+func jsonrpcID(strict bool) *Schema {
+	if strict {
+		return &Schema{Type: []string{"string", "number", "null"}}
+	}
+	return &Schema{Type: "string"}
+}
+
+func operationID(prefix, uri string) string {
+	if name := strings.Trim(rexComponentName.ReplaceAllString(uri, "_"), "_"); len(name) > 0 {
+		return prefix + "_" + name
+	}
+	return prefix
+}
+
+// convertPath converts the router path with `{name:regex}` params to the OpenAPI path with parameters
+func convertPath(uri string) (string, []Parameter) {
+	var params []Parameter
+
+	segments := strings.Split(strings.ToLower(uri), "/")
+	result := make([]string, 0, len(segments))
+	for _, segment := range segments {
+		if len(segment) == 0 {
+			continue
+		}
+		if segment == anyPath {
+			params = append(params, Parameter{
+				Name: "path", In: "path", Required: true, Schema: &Schema{Type: "string"},
+			})
+			result = append(result, "{path}")
+			continue
+		}
+		segment = rexPathParams.ReplaceAllStringFunc(segment, func(s string) string {
+			match := rexPathParams.FindStringSubmatch(s)
+			schema := &Schema{Type: "string"}
+			if len(match[2]) > 0 {
+				schema.Pattern = "^" + match[2] + "$"
+			}
+			params = append(params, Parameter{
+				Name: match[1], In: "path", Required: true, Schema: schema,
+			})
+			return "{" + match[1] + "}"
+		})
+		result = append(result, segment)
+	}
+
+	return "/" + strings.Join(result, "/"), params
+}
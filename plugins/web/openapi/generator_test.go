@@ -0,0 +1,115 @@
+/*
+ *  Copyright (c) 2022-2026 Mikhail Knyazhev <markus621@yandex.com>. All rights reserved.
+ *  Use of this source code is governed by a BSD 3-Clause license that can be found in the LICENSE file.
+ */
+
+package openapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"go.osspkg.com/casecheck"
+
+	"go.osspkg.com/goppy/v3/plugins/web"
+	"go.osspkg.com/goppy/v3/plugins/web/jsonrpc"
+)
+
+type (
+	testBase struct {
+		ID int64 `json:"id"`
+	}
+	testUser struct {
+		testBase
+		Name    string            `json:"name"`
+		Email   string            `json:"email,omitempty"`
+		Created time.Time         `json:"created"`
+		Tags    []string          `json:"tags"`
+		Meta    map[string]int    `json:"meta,omitempty"`
+		Parent  *testUser         `json:"parent"`
+		Raw     json.RawMessage   `json:"raw"`
+		Secret  string            `json:"-"`
+		private string            //nolint:unused
+		Extra   map[string]string `json:"extra,omitempty"`
+	}
+	testUserRequest struct {
+		User testUser `json:"user"`
+	}
+)
+
+func TestUnit_ConvertPath(t *testing.T) {
+	uri, params := convertPath("/Users/{id:\\d+}/files/{name}.{ext:[a-z]+}/#")
+	casecheck.Equal(t, "/users/{id}/files/{name}.{ext}/{path}", uri)
+	casecheck.Equal(t, []Parameter{
+		{Name: "id", In: "path", Required: true, Schema: &Schema{Type: "string", Pattern: "^\\d+$"}},
+		{Name: "name", In: "path", Required: true, Schema: &Schema{Type: "string"}},
+		{Name: "ext", In: "path", Required: true, Schema: &Schema{Type: "string", Pattern: "^[a-z]+$"}},
+		{Name: "path", In: "path", Required: true, Schema: &Schema{Type: "string"}},
+	}, params)
+}
+
+func TestUnit_Schema(t *testing.T) {
+	s := newSchemas()
+
+	casecheck.Equal(t, &Schema{Ref: "#/components/schemas/testUser"}, s.Of(&testUser{}))
+	casecheck.Equal(t, &Schema{Type: "array", Items: &Schema{Ref: "#/components/schemas/testUser"}}, s.Of([]testUser{}))
+
+	b, err := json.Marshal(s.Components())
+	casecheck.NoError(t, err)
+	casecheck.Equal(t, `{"testUser":{"type":"object","properties":{`+
+		`"created":{"type":"string","format":"date-time"},`+
+		`"email":{"type":"string"},`+
+		`"extra":{"type":"object","additionalProperties":{"type":"string"}},`+
+		`"id":{"type":"integer","format":"int64"},`+
+		`"meta":{"type":"object","additionalProperties":{"type":"integer","format":"int64"}},`+
+		`"name":{"type":"string"},`+
+		`"parent":{"$ref":"#/components/schemas/testUser"},`+
+		`"raw":{},`+
+		`"tags":{"type":"array","items":{"type":"string"}}},`+
+		`"required":["id","name","created","tags","raw"]}}`, string(b))
+}
+
+func TestUnit_Generator(t *testing.T) {
+	g := NewGenerator("test", "1.0.0")
+	g.AddRoutes([]web.RouteInfo{
+		{Method: http.MethodGet, Path: "/users/{id:\\d+}"},
+		{Method: http.MethodPost, Path: "/users"},
+		{Method: http.MethodPost, Path: "/rpc"},
+	})
+	g.AddOperation(http.MethodPost, "/users", &testUserRequest{}, &testBase{})
+	g.AddJSONRPC(jsonrpc.TDescription{
+		Path: "/rpc",
+		Models: map[string]jsonrpc.TModel{
+			"user.get": {Request: &struct {
+				ID int64 `json:"id"`
+			}{}, Response: &testUser{}},
+		},
+	})
+
+	doc := g.Document()
+	casecheck.Equal(t, Version, doc.OpenAPI)
+	casecheck.Equal(t, 3, len(doc.Paths))
+
+	op := doc.Paths["/users/{id}"]["get"]
+	casecheck.Equal(t, "get_users_id", op.OperationID)
+	casecheck.Equal(t, "id", op.Parameters[0].Name)
+
+	op = doc.Paths["/users"]["post"]
+	casecheck.Equal(t, &Schema{Ref: "#/components/schemas/testUserRequest"}, op.RequestBody.Content[mimeJSON].Schema)
+	casecheck.Equal(t, &Schema{Ref: "#/components/schemas/testBase"}, op.Responses["200"].Content[mimeJSON].Schema)
+
+	op = doc.Paths["/rpc"]["post"]
+	casecheck.Equal(t, "jsonrpc_rpc", op.OperationID)
+	item := op.RequestBody.Content[mimeJSON].Schema.Items.OneOf[0]
+	casecheck.Equal(t, "user.get", item.Properties["method"].Const)
+	casecheck.Equal(t, &Schema{Ref: "#/components/schemas/user.get.request"}, item.Properties["params"])
+	casecheck.Equal(t, &Schema{Ref: "#/components/schemas/testUser"},
+		op.Responses["200"].Content[mimeJSON].Schema.Items.OneOf[0].Properties["result"])
+
+	for _, name := range []string{"testUser", "testUserRequest", "testBase", "user.get.request", "jsonrpc.error"} {
+		_, ok := doc.Components.Schemas[name]
+		casecheck.True(t, ok, name)
+	}
+}
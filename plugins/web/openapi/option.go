@@ -0,0 +1,48 @@
+/*
+ *  Copyright (c) 2022-2026 Mikhail Knyazhev <markus621@yandex.com>. All rights reserved.
+ *  Use of this source code is governed by a BSD 3-Clause license that can be found in the LICENSE file.
+ */
+
+package openapi
+
+type Option func(o *options)
+
+type options struct {
+	title   string
+	version string
+	path    string
+	tag     string
+}
+
+// Title of the API in the document
+func Title(arg string) Option {
+	return func(o *options) {
+		o.title = arg
+	}
+}
+
+// APIVersion version of the API in the document
+func APIVersion(arg string) Option {
+	return func(o *options) {
+		o.version = arg
+	}
+}
+
+// Path of the document route on the admin server
+func Path(arg string) Option {
+	return func(o *options) {
+		if len(arg) == 0 {
+			arg = "/"
+		}
+		o.path = arg
+	}
+}
+
+// Tag of the server which routes are described by default
+func Tag(arg string) Option {
+	return func(o *options) {
+		if len(arg) > 0 {
+			o.tag = arg
+		}
+	}
+}
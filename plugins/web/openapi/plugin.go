@@ -0,0 +1,31 @@
+/*
+ *  Copyright (c) 2022-2026 Mikhail Knyazhev <markus621@yandex.com>. All rights reserved.
+ *  Use of this source code is governed by a BSD 3-Clause license that can be found in the LICENSE file.
+ */
+
+package openapi
+
+import (
+	"go.osspkg.com/goppy/v3/plugin"
+	"go.osspkg.com/goppy/v3/plugins/web"
+	"go.osspkg.com/goppy/v3/plugins/web/jsonrpc"
+)
+
+// WithOpenAPI serves the OpenAPI document of the web server routes on the admin server
+func WithOpenAPI(opts ...Option) plugin.Kind {
+	return plugin.Kind{
+		Inject: func(r web.ServerPool) OpenAPI {
+			return newService(r, opts...)
+		},
+	}
+}
+
+// WithJSONRPC adds the JSON-RPC transport methods to the OpenAPI document
+func WithJSONRPC() plugin.Kind {
+	return plugin.Kind{
+		Inject: func(o OpenAPI, t jsonrpc.Transport) error {
+			o.AddJSONRPC(t)
+			return nil
+		},
+	}
+}
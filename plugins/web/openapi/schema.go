@@ -0,0 +1,193 @@
+/*
+ *  Copyright (c) 2022-2026 Mikhail Knyazhev <markus621@yandex.com>. All rights reserved.
+ *  Use of this source code is governed by a BSD 3-Clause license that can be found in the LICENSE file.
+ */
+
+package openapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"path"
+	"reflect"
+	"regexp"
+	"strings"
+	"time"
+)
+
+var (
+	typeTime       = reflect.TypeOf(time.Time{})
+	typeRawMessage = reflect.TypeOf(json.RawMessage{})
+
+	rexComponentName = regexp.MustCompile(`[^a-zA-Z0-9._-]+`)
+)
+
+// schemas registry of the component schemas built from the Go types
+type schemas struct {
+	items map[string]*Schema
+	names map[reflect.Type]string
+}
+
+func newSchemas() *schemas {
+	return &schemas{
+		items: make(map[string]*Schema, 10),
+		names: make(map[reflect.Type]string, 10),
+	}
+}
+
+// Of returns the schema of the value, named structures are added to the components
+func (v *schemas) Of(arg any) *Schema {
+	if arg == nil {
+		return &Schema{}
+	}
+	return v.build(reflect.TypeOf(arg))
+}
+
+// Named returns the schema of the value added to the components under the specified name
+func (v *schemas) Named(name string, arg any) *Schema {
+	if arg == nil {
+		return &Schema{}
+	}
+
+	t := reflect.TypeOf(arg)
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return v.build(t)
+	}
+
+	name = v.freeName(rexComponentName.ReplaceAllString(name, "_"), t)
+	return v.ref(name, t)
+}
+
+func (v *schemas) Components() map[string]*Schema {
+	return v.items
+}
+
+func (v *schemas) build(t reflect.Type) *Schema {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	switch t {
+	case typeTime:
+		return &Schema{Type: "string", Format: "date-time"}
+	case typeRawMessage:
+		return &Schema{}
+	default:
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int64, reflect.Uint, reflect.Uint64:
+		return &Schema{Type: "integer", Format: "int64"}
+	case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return &Schema{Type: "integer", Format: "int32"}
+	case reflect.Float32:
+		return &Schema{Type: "number", Format: "float"}
+	case reflect.Float64:
+		return &Schema{Type: "number", Format: "double"}
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return &Schema{Type: "string", Format: "byte"}
+		}
+		return &Schema{Type: "array", Items: v.build(t.Elem())}
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: v.build(t.Elem())}
+	case reflect.Struct:
+		if len(t.Name()) == 0 {
+			return v.object(t)
+		}
+		if name, ok := v.names[t]; ok {
+			return &Schema{Ref: componentsPrefix + name}
+		}
+		return v.ref(v.freeName(v.typeName(t), t), t)
+	default:
+		return &Schema{}
+	}
+}
+
+func (v *schemas) ref(name string, t reflect.Type) *Schema {
+	if _, ok := v.items[name]; !ok {
+		v.names[t] = name
+		v.items[name] = &Schema{}
+		*v.items[name] = *v.object(t)
+	}
+	return &Schema{Ref: componentsPrefix + name}
+}
+
+func (v *schemas) typeName(t reflect.Type) string {
+	return rexComponentName.ReplaceAllString(t.Name(), "_")
+}
+
+func (v *schemas) freeName(name string, t reflect.Type) string {
+	if prev, ok := v.names[t]; ok {
+		return prev
+	}
+	if _, ok := v.items[name]; !ok {
+		return name
+	}
+	if pkg := path.Base(t.PkgPath()); len(pkg) > 0 && pkg != "." {
+		name = rexComponentName.ReplaceAllString(pkg, "_") + "." + name
+	}
+	result := name
+	for i := 2; ; i++ {
+		if _, ok := v.items[result]; !ok {
+			return result
+		}
+		result = fmt.Sprintf("%s%d", name, i)
+	}
+}
+
+func (v *schemas) object(t reflect.Type) *Schema {
+	result := &Schema{
+		Type:       "object",
+		Properties: make(map[string]*Schema, t.NumField()),
+	}
+	v.fields(result, t)
+	return result
+}
+
+func (v *schemas) fields(result *Schema, t reflect.Type) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, opts, _ := strings.Cut(tag, ",")
+
+		if field.Anonymous && len(name) == 0 {
+			ft := field.Type
+			for ft.Kind() == reflect.Pointer {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				v.fields(result, ft)
+				continue
+			}
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if len(name) == 0 {
+			name = field.Name
+		}
+
+		schema := v.build(field.Type)
+		if strings.Contains(opts, "string") && len(schema.Ref) == 0 {
+			schema = &Schema{Type: "string"}
+		}
+		result.Properties[name] = schema
+
+		if !strings.Contains(opts, "omitempty") && !strings.Contains(opts, "omitzero") &&
+			field.Type.Kind() != reflect.Pointer {
+			result.Required = append(result.Required, name)
+		}
+	}
+}
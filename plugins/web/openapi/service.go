@@ -0,0 +1,120 @@
+/*
+ *  Copyright (c) 2022-2026 Mikhail Knyazhev <markus621@yandex.com>. All rights reserved.
+ *  Use of this source code is governed by a BSD 3-Clause license that can be found in the LICENSE file.
+ */
+
+package openapi
+
+import (
+	"net/http"
+	"sync"
+
+	"go.osspkg.com/logx"
+
+	"go.osspkg.com/goppy/v3/plugins/web"
+	"go.osspkg.com/goppy/v3/plugins/web/jsonrpc"
+)
+
+// OpenAPI runtime generator of the OpenAPI document for the web servers
+type OpenAPI interface {
+	Describe(tag, method, path string, req, res any)
+	AddJSONRPC(t jsonrpc.Transport)
+	Document(tag string) *Document
+	Handler(ctx web.Ctx)
+}
+
+type (
+	service struct {
+		opt        *options
+		routes     web.ServerPool
+		operations []operation
+		transports []jsonrpc.Transport
+		mux        sync.RWMutex
+	}
+
+	operation struct {
+		tag, method, path string
+		req, res          any
+	}
+)
+
+func newService(routes web.ServerPool, opts ...Option) *service {
+	obj := &service{
+		opt: &options{
+			title:   "API",
+			version: "1.0.0",
+			path:    "/openapi.json",
+			tag:     "main",
+		},
+		routes: routes,
+	}
+
+	for _, o := range opts {
+		o(obj.opt)
+	}
+
+	return obj
+}
+
+func (v *service) Up() error {
+	r, ok := v.routes.Admin()
+	if !ok {
+		logx.Warn("OpenAPI", "do", "skip", "err", "admin server not found")
+		return nil
+	}
+
+	r.Get(v.opt.path, v.Handler)
+
+	logx.Info("OpenAPI", "do", "start", "path", v.opt.path)
+	return nil
+}
+
+func (v *service) Down() error {
+	return nil
+}
+
+// Describe sets request and response models of the route
+func (v *service) Describe(tag, method, path string, req, res any) {
+	v.mux.Lock()
+	v.operations = append(v.operations, operation{tag: tag, method: method, path: path, req: req, res: res})
+	v.mux.Unlock()
+}
+
+// AddJSONRPC adds the JSON-RPC transport methods to the documents
+func (v *service) AddJSONRPC(t jsonrpc.Transport) {
+	v.mux.Lock()
+	v.transports = append(v.transports, t)
+	v.mux.Unlock()
+}
+
+// Document builds the document for the server with the tag
+func (v *service) Document(tag string) *Document {
+	g := NewGenerator(v.opt.title, v.opt.version)
+
+	if r, ok := v.routes.ByTag(tag); ok {
+		g.AddRoutes(r.Routes())
+	}
+
+	v.mux.RLock()
+	defer v.mux.RUnlock()
+
+	for _, op := range v.operations {
+		if op.tag == tag {
+			g.AddOperation(op.method, op.path, op.req, op.res)
+		}
+	}
+	for _, t := range v.transports {
+		g.AddJSONRPC(t.Describe(tag))
+	}
+
+	return g.Document()
+}
+
+// Handler sends the document, the server tag can be changed by the `tag` query param
+func (v *service) Handler(ctx web.Ctx) {
+	tag := ctx.Query("tag")
+	if len(tag) == 0 {
+		tag = v.opt.tag
+	}
+	ctx.JSON(http.StatusOK, v.Document(tag))
+}
@@ -16,3 +16,15 @@ func WithServer() plugin.Kind {
 		},
 	}
 }
+
+// WithRouteTable dumps the routes of all servers of the pool on the admin server
+func WithRouteTable(path string) plugin.Kind {
+	return plugin.Kind{
+		Inject: func(pool ServerPool) error {
+			if r, ok := pool.Admin(); ok {
+				r.Get(path, RouteTableHandler(pool))
+			}
+			return nil
+		},
+	}
+}
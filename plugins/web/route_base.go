@@ -6,6 +6,7 @@
 package web
 
 import (
+	"fmt"
 	"net/http"
 	"sync"
 )
@@ -14,12 +15,16 @@ var _ http.Handler = (*BaseRouter)(nil)
 
 type BaseRouter struct {
 	handler *ctrlHandler
+	routes  []RouteInfo
+	names   map[string]string
+	opts    ctxOptions
 	mux     sync.RWMutex
 }
 
 func NewBaseRouter() *BaseRouter {
 	return &BaseRouter{
 		handler: newCtrlHandler(),
+		names:   make(map[string]string),
 	}
 }
 
@@ -27,9 +32,25 @@ func NewBaseRouter() *BaseRouter {
 func (v *BaseRouter) Route(path string, ctrl func(ctx Ctx), methods ...string) {
 	v.mux.Lock()
 	v.handler.Route(path, ctrl, methods)
+	v.addRouteInfo("", path, methods)
 	v.mux.Unlock()
 }
 
+// NamedRoute add new route with the name for building URL by URLFor
+func (v *BaseRouter) NamedRoute(name, path string, ctrl func(ctx Ctx), methods ...string) {
+	v.mux.Lock()
+	defer v.mux.Unlock()
+
+	pattern := routePattern(path)
+	if prev, ok := v.names[name]; ok && prev != pattern {
+		panic(fmt.Sprintf("route name `%s` already used for `%s`", name, prev))
+	}
+	v.names[name] = pattern
+
+	v.handler.Route(path, ctrl, methods)
+	v.addRouteInfo(name, path, methods)
+}
+
 // Global add global middlewares
 func (v *BaseRouter) Global(middlewares ...Middleware) {
 	v.mux.Lock()
@@ -51,14 +72,52 @@ func (v *BaseRouter) NoFoundHandler(call func(ctx Ctx)) {
 	v.mux.Unlock()
 }
 
+// TrustedProxies set networks of the proxies which forwarding headers are used by Ctx.ClientIP,
+// the value can be a CIDR or a single IP
+func (v *BaseRouter) TrustedProxies(cidrs ...string) error {
+	proxies, err := parseTrustedProxies(cidrs)
+	if err != nil {
+		return err
+	}
+
+	v.mux.Lock()
+	v.opts.proxies = proxies
+	v.mux.Unlock()
+	return nil
+}
+
+// MaxBodySize set the default limit of the request body for Ctx.Bind* methods, zero disables the limit,
+// the route can override it by BodyLimitMiddleware
+func (v *BaseRouter) MaxBodySize(size int64) {
+	v.mux.Lock()
+	v.opts.maxBodySize = size
+	v.mux.Unlock()
+}
+
+// StrictJSON set the default mode of Ctx.BindJSON which rejects unknown fields and trailing data,
+// the route can override it by StrictJSONMiddleware
+func (v *BaseRouter) StrictJSON(strict bool) {
+	v.mux.Lock()
+	v.opts.strictJSON = strict
+	v.mux.Unlock()
+}
+
+// Tag set the server tag available by Ctx.ServerTag
+func (v *BaseRouter) Tag(tag string) {
+	v.mux.Lock()
+	v.opts.tag = tag
+	v.mux.Unlock()
+}
+
 // ServeHTTP http interface
 func (v *BaseRouter) ServeHTTP(w http.ResponseWriter, r *http.Request) {
-	ctx := NewCtx(w, r)
-
 	v.mux.RLock()
-	code, next, params, midd := v.handler.Match(r.URL.Path, r.Method)
+	ctx := newCtx(w, r, v.opts)
+	code, next, params, midd, pattern := v.handler.Match(r.URL.Path, r.Method)
 	v.mux.RUnlock()
 
+	ctx.pattern = pattern
+
 	if code != http.StatusOK {
 		next = codeHandler(code)
 	}
@@ -159,3 +159,65 @@ func BenchmarkRouter1(b *testing.B) {
 		}
 	})
 }
+
+func TestUnit_RouteInfo(t *testing.T) {
+	r := web.NewBaseRouter()
+	r.Global(web.RecoveryMiddleware())
+	r.Middlewares("/users", web.ThrottlingMiddleware(10))
+	r.Route("/users/{id:\\d+}", func(web.Ctx) {}, http.MethodGet, "delete")
+	r.Route("/users", func(web.Ctx) {}, http.MethodPost)
+	r.NamedRoute("user.list", "/Users/", func(web.Ctx) {}, http.MethodGet, http.MethodPost)
+	r.Route("/about", func(web.Ctx) {}, http.MethodGet)
+	r.Middlewares("/users/{id:\\d+}/posts", web.BodyLimitMiddleware(1024))
+	r.Route("/users/{id:\\d+}/posts", func(web.Ctx) {}, http.MethodPost)
+	r.Middlewares("/files/#", web.StrictJSONMiddleware(true))
+	r.Route("/files/#", func(web.Ctx) {}, http.MethodGet)
+
+	mw := []string{"web.RecoveryMiddleware", "web.ThrottlingMiddleware"}
+	casecheck.Equal(t, []web.RouteInfo{
+		{Method: http.MethodGet, Path: "/about", Middlewares: mw[:1]},
+		{Method: http.MethodGet, Path: "/files/#", Middlewares: []string{"web.RecoveryMiddleware", "web.StrictJSONMiddleware"}},
+		{Name: "user.list", Method: http.MethodGet, Path: "/users", Middlewares: mw},
+		{Name: "user.list", Method: http.MethodPost, Path: "/users", Middlewares: mw},
+		{Method: http.MethodDelete, Path: "/users/{id:\\d+}", Middlewares: mw},
+		{Method: http.MethodGet, Path: "/users/{id:\\d+}", Middlewares: mw},
+		{Method: http.MethodPost, Path: "/users/{id:\\d+}/posts", Middlewares: append(mw, "web.BodyLimitMiddleware")},
+	}, r.Routes())
+}
+
+func TestUnit_URLFor(t *testing.T) {
+	r := web.NewBaseRouter()
+	r.NamedRoute("home", "/", func(web.Ctx) {}, http.MethodGet)
+	r.NamedRoute("user.show", "/users/{id:\\d+}/{name}", func(web.Ctx) {}, http.MethodGet)
+	r.NamedRoute("file", "/files/{name}.{ext:[a-z]+}", func(web.Ctx) {}, http.MethodGet)
+	r.NamedRoute("static", "/static/#", func(web.Ctx) {}, http.MethodGet)
+
+	tests := []struct {
+		name string
+		args []any
+		want string
+		err  bool
+	}{
+		{name: "home", want: "/"},
+		{name: "home", args: []any{"page", 2}, want: "/?page=2"},
+		{name: "user.show", args: []any{"id", 42, "name", "a b"}, want: "/users/42/a%20b"},
+		{name: "user.show", args: []any{"id", 42, "name", "x", "tab", "info"}, want: "/users/42/x?tab=info"},
+		{name: "user.show", args: []any{"id", "abc", "name", "x"}, err: true},
+		{name: "user.show", args: []any{"id", 1}, err: true},
+		{name: "user.show", args: []any{"id"}, err: true},
+		{name: "file", args: []any{"name", "doc", "ext", "txt"}, want: "/files/doc.txt"},
+		{name: "static", args: []any{"#", "css/main.css"}, want: "/static/css/main.css"},
+		{name: "unknown", err: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := r.URLFor(tt.name, tt.args...)
+			if tt.err {
+				casecheck.Error(t, err)
+				return
+			}
+			casecheck.NoError(t, err)
+			casecheck.Equal(t, tt.want, got)
+		})
+	}
+}
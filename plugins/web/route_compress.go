@@ -0,0 +1,300 @@
+/*
+ *  Copyright (c) 2022-2026 Mikhail Knyazhev <markus621@yandex.com>. All rights reserved.
+ *  Use of this source code is governed by a BSD 3-Clause license that can be found in the LICENSE file.
+ */
+
+package web
+
+import (
+	"compress/flate"
+	"compress/gzip"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+
+	"go.osspkg.com/logx"
+
+	"go.osspkg.com/goppy/v3/pkg/mime"
+)
+
+const defaultCompressionMinSize = 1024
+
+var defaultCompressionMIME = []string{
+	"text/*",
+	"application/json",
+	"application/xml",
+	"application/javascript",
+	"application/x-javascript",
+	"image/svg+xml",
+}
+
+type (
+	// Compressor encoder of the response body for the Content-Encoding,
+	// only gzip and deflate are shipped, br or zstd can be added with an own implementation
+	// on top of a third-party encoder, e.g. github.com/andybalholm/brotli
+	Compressor interface {
+		Encoding() string
+		Writer(w io.Writer) (io.WriteCloser, error)
+	}
+
+	// CompressionConfig setting of the compression middleware
+	CompressionConfig struct {
+		// MinSize minimum size of the body for compression, 1024 by default
+		MinSize int
+		// MIME allow-list of the content types, supports `type/*`, text and json types by default
+		MIME []string
+		// Compressors in order of priority, gzip and deflate by default
+		Compressors []Compressor
+	}
+)
+
+// CompressionMiddleware compresses the response body with the encoding selected by the Accept-Encoding header,
+// the content type is detected by pkg/mime if the handler did not set it
+func CompressionMiddleware(conf CompressionConfig) Middleware {
+	if conf.MinSize <= 0 {
+		conf.MinSize = defaultCompressionMinSize
+	}
+	if len(conf.MIME) == 0 {
+		conf.MIME = defaultCompressionMIME
+	}
+	if len(conf.Compressors) == 0 {
+		conf.Compressors = []Compressor{GzipCompressor(gzip.DefaultCompression), DeflateCompressor(flate.DefaultCompression)}
+	}
+
+	offers := make([]string, 0, len(conf.Compressors))
+	compressors := make(map[string]Compressor, len(conf.Compressors))
+	for _, item := range conf.Compressors {
+		offers = append(offers, item.Encoding())
+		compressors[strings.ToLower(item.Encoding())] = item
+	}
+
+	return func(call func(Ctx)) func(Ctx) {
+		return func(ctx Ctx) {
+			r := ctx.Request()
+			values := r.Header.Values("Accept-Encoding")
+			if len(values) == 0 || r.Method == http.MethodHead || len(r.Header.Get("Upgrade")) > 0 {
+				call(ctx)
+				return
+			}
+			compressor, ok := compressors[strings.ToLower(negotiate(values, offers...))]
+			if !ok {
+				call(ctx)
+				return
+			}
+
+			c, ok := ctx.(*_ctx)
+			if !ok {
+				call(ctx)
+				return
+			}
+
+			w := &compressWriter{
+				ResponseWriter: c.w,
+				conf:           &conf,
+				compressor:     compressor,
+			}
+			c.w = w
+			defer func() {
+				c.w = w.ResponseWriter
+				if err := w.Close(); err != nil {
+					logx.Error("web.CompressionMiddleware", "err", err)
+				}
+			}()
+
+			call(ctx)
+		}
+	}
+}
+
+/**********************************************************************************************************************/
+
+type compressWriter struct {
+	http.ResponseWriter
+	conf       *CompressionConfig
+	compressor Compressor
+	out        io.WriteCloser
+	buf        []byte
+	code       int
+	decided    bool
+}
+
+func (w *compressWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+func (w *compressWriter) WriteHeader(code int) {
+	if w.decided {
+		w.ResponseWriter.WriteHeader(code)
+		return
+	}
+	if w.code == 0 {
+		w.code = code
+	}
+}
+
+func (w *compressWriter) Write(b []byte) (int, error) {
+	if w.decided {
+		if w.out != nil {
+			return w.out.Write(b)
+		}
+		return w.ResponseWriter.Write(b)
+	}
+
+	w.buf = append(w.buf, b...)
+	if len(w.buf) < w.conf.MinSize {
+		return len(b), nil
+	}
+	if err := w.decide(); err != nil {
+		return 0, err
+	}
+	return len(b), nil
+}
+
+func (w *compressWriter) Flush() {
+	if !w.decided {
+		if err := w.decide(); err != nil {
+			logx.Error("web.CompressionMiddleware", "err", err)
+			return
+		}
+	}
+	if f, ok := w.out.(interface{ Flush() error }); ok {
+		if err := f.Flush(); err != nil {
+			logx.Error("web.CompressionMiddleware", "err", err)
+		}
+	}
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+func (w *compressWriter) Close() error {
+	if !w.decided {
+		if err := w.decide(); err != nil {
+			return err
+		}
+	}
+	if w.out != nil {
+		return w.out.Close()
+	}
+	return nil
+}
+
+func (w *compressWriter) decide() error {
+	w.decided = true
+
+	h := w.Header()
+	if len(h.Get("Content-Type")) == 0 && len(w.buf) > 0 {
+		h.Set("Content-Type", mime.DetectByContent(w.buf))
+	}
+	if w.code == 0 {
+		w.code = http.StatusOK
+	}
+
+	if w.allowed(h) {
+		h.Add("Vary", "Accept-Encoding")
+
+		if len(w.buf) >= w.conf.MinSize {
+			out, err := w.compressor.Writer(w.ResponseWriter)
+			if err != nil {
+				return err
+			}
+			h.Set("Content-Encoding", w.compressor.Encoding())
+			h.Del("Content-Length")
+			w.out = out
+		}
+	}
+
+	w.ResponseWriter.WriteHeader(w.code)
+
+	buf := w.buf
+	w.buf = nil
+	if len(buf) == 0 {
+		return nil
+	}
+	if w.out != nil {
+		_, err := w.out.Write(buf)
+		return err
+	}
+	_, err := w.ResponseWriter.Write(buf)
+	return err
+}
+
+func (w *compressWriter) allowed(h http.Header) bool {
+	if w.code < http.StatusOK || w.code == http.StatusNoContent || w.code == http.StatusNotModified ||
+		w.code == http.StatusPartialContent || len(h.Get("Content-Encoding")) > 0 {
+		return false
+	}
+
+	ct, _, _ := strings.Cut(h.Get("Content-Type"), ";")
+	ct = strings.ToLower(strings.TrimSpace(ct))
+	for _, pattern := range w.conf.MIME {
+		if acceptMatch(strings.ToLower(pattern), ct) > 1 {
+			return true
+		}
+	}
+	return false
+}
+
+/**********************************************************************************************************************/
+
+type (
+	poolCompressor struct {
+		encoding string
+		pool     sync.Pool
+		create   func(w io.Writer) (resetWriter, error)
+	}
+
+	resetWriter interface {
+		io.WriteCloser
+		Flush() error
+		Reset(w io.Writer)
+	}
+
+	pooledWriter struct {
+		resetWriter
+		c *poolCompressor
+	}
+)
+
+// GzipCompressor gzip encoding with the compression level of compress/gzip
+func GzipCompressor(level int) Compressor {
+	return &poolCompressor{
+		encoding: "gzip",
+		create: func(w io.Writer) (resetWriter, error) {
+			return gzip.NewWriterLevel(w, level)
+		},
+	}
+}
+
+// DeflateCompressor deflate encoding with the compression level of compress/flate
+func DeflateCompressor(level int) Compressor {
+	return &poolCompressor{
+		encoding: "deflate",
+		create: func(w io.Writer) (resetWriter, error) {
+			return flate.NewWriter(w, level)
+		},
+	}
+}
+
+func (v *poolCompressor) Encoding() string {
+	return v.encoding
+}
+
+func (v *poolCompressor) Writer(w io.Writer) (io.WriteCloser, error) {
+	if rw, ok := v.pool.Get().(resetWriter); ok {
+		rw.Reset(w)
+		return &pooledWriter{resetWriter: rw, c: v}, nil
+	}
+	rw, err := v.create(w)
+	if err != nil {
+		return nil, err
+	}
+	return &pooledWriter{resetWriter: rw, c: v}, nil
+}
+
+func (v *pooledWriter) Close() error {
+	err := v.resetWriter.Close()
+	v.c.pool.Put(v.resetWriter)
+	return err
+}
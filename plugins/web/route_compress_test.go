@@ -0,0 +1,115 @@
+/*
+ *  Copyright (c) 2022-2026 Mikhail Knyazhev <markus621@yandex.com>. All rights reserved.
+ *  Use of this source code is governed by a BSD 3-Clause license that can be found in the LICENSE file.
+ */
+
+package web
+
+import (
+	"compress/gzip"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"go.osspkg.com/casecheck"
+)
+
+func TestUnit_Negotiate(t *testing.T) {
+	tests := []struct {
+		header string
+		offers []string
+		want   string
+	}{
+		{header: "", offers: []string{mimeJSON, mimeXML}, want: mimeJSON},
+		{header: "application/xml", offers: []string{mimeJSON, mimeXML}, want: mimeXML},
+		{header: "text/html, application/*;q=0.5", offers: []string{mimeJSON, mimeXML}, want: mimeJSON},
+		{header: "application/json;q=0.2, application/xml;q=0.9", offers: []string{mimeJSON, mimeXML}, want: mimeXML},
+		{header: "*/*;q=0.1, application/json;q=0", offers: []string{mimeJSON, mimeXML}, want: mimeXML},
+		{header: "text/html", offers: []string{mimeJSON, mimeXML}, want: ""},
+		{header: "gzip;q=0.5, br", offers: []string{"gzip", "deflate"}, want: "gzip"},
+		{header: "*, gzip;q=0", offers: []string{"gzip", "deflate"}, want: "deflate"},
+		{header: "identity", offers: []string{"gzip", "deflate"}, want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.header, func(t *testing.T) {
+			casecheck.Equal(t, tt.want, negotiate([]string{tt.header}, tt.offers...))
+		})
+	}
+}
+
+func TestUnit_Ctx_Negotiate(t *testing.T) {
+	type model struct {
+		Name string `json:"name" xml:"name"`
+	}
+
+	for header, want := range map[string]string{
+		"":                `{"name":"a"}`,
+		"application/xml": `<model><name>a</name></model>`,
+	} {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, "/", nil)
+		r.Header.Set("Accept", header)
+		NewCtx(w, r).Negotiate(http.StatusOK, &model{Name: "a"})
+		casecheck.Equal(t, http.StatusOK, w.Code)
+		casecheck.Equal(t, want, w.Body.String())
+	}
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	r.Header.Set("Accept", "text/html")
+	NewCtx(w, r).Negotiate(http.StatusOK, &model{Name: "a"})
+	casecheck.Equal(t, http.StatusNotAcceptable, w.Code)
+}
+
+func TestUnit_CompressionMiddleware(t *testing.T) {
+	big := strings.Repeat("hello world ", 200)
+
+	r := NewBaseRouter()
+	r.Global(CompressionMiddleware(CompressionConfig{}))
+	r.Route("/big", func(ctx Ctx) { ctx.String(http.StatusOK, big) }, http.MethodGet)
+	r.Route("/small", func(ctx Ctx) { ctx.String(http.StatusOK, "hello") }, http.MethodGet)
+	r.Route("/bin", func(ctx Ctx) { ctx.Raw(http.StatusOK, "image/png", []byte(big)) }, http.MethodGet)
+	r.Route("/detect", func(ctx Ctx) {
+		_, _ = ctx.Response().Write([]byte("<html><body>" + big + "</body></html>"))
+	}, http.MethodGet)
+
+	send := func(uri, encoding string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, uri, nil)
+		if len(encoding) > 0 {
+			req.Header.Set("Accept-Encoding", encoding)
+		}
+		r.ServeHTTP(w, req)
+		return w
+	}
+
+	w := send("/big", "br, gzip")
+	casecheck.Equal(t, http.StatusOK, w.Code)
+	casecheck.Equal(t, "gzip", w.Header().Get("Content-Encoding"))
+	casecheck.Equal(t, "Accept-Encoding", w.Header().Get("Vary"))
+	gz, err := gzip.NewReader(w.Body)
+	casecheck.NoError(t, err)
+	b, err := io.ReadAll(gz)
+	casecheck.NoError(t, err)
+	casecheck.Equal(t, big, string(b))
+
+	w = send("/big", "deflate")
+	casecheck.Equal(t, "deflate", w.Header().Get("Content-Encoding"))
+
+	w = send("/big", "")
+	casecheck.Equal(t, "", w.Header().Get("Content-Encoding"))
+	casecheck.Equal(t, big, w.Body.String())
+
+	w = send("/small", "gzip")
+	casecheck.Equal(t, "", w.Header().Get("Content-Encoding"))
+	casecheck.Equal(t, "hello", w.Body.String())
+
+	w = send("/bin", "gzip")
+	casecheck.Equal(t, "", w.Header().Get("Content-Encoding"))
+
+	w = send("/detect", "gzip")
+	casecheck.Equal(t, "gzip", w.Header().Get("Content-Encoding"))
+	casecheck.Contains(t, w.Header().Get("Content-Type"), "text/html")
+}
@@ -18,6 +18,7 @@ type ctrlHandler struct {
 	matcher     *paramMatch
 	middlewares []Middleware
 	notFound    func(ctx Ctx)
+	pattern     string
 }
 
 func newCtrlHandler() *ctrlHandler {
@@ -68,6 +69,7 @@ func (v *ctrlHandler) Route(path string, ctrl func(ctx Ctx), methods []string) {
 	for _, m := range methods {
 		uh.methods[strings.ToUpper(m)] = ctrl
 	}
+	uh.pattern = routePattern(path)
 }
 
 // Middlewares add middleware to route
@@ -80,12 +82,32 @@ func (v *ctrlHandler) Middlewares(path string, middlewares ...Middleware) {
 	uh.middlewares = append(uh.middlewares, middlewares...)
 }
 
+// MiddlewaresOf list of the middlewares applied to the route,
+// the param and `#` segments are walked the same way as in Match
+func (v *ctrlHandler) MiddlewaresOf(path string) []Middleware {
+	fork := v
+	result := append(make([]Middleware, 0, len(v.middlewares)), v.middlewares...)
+
+	vr := uriParamData{}
+	var isBreak bool
+	for _, uri := range urlSplit(path) {
+		if fork, isBreak = fork.next(uri, vr); fork == nil {
+			break
+		}
+		result = append(result, fork.middlewares...)
+		if isBreak {
+			break
+		}
+	}
+	return result
+}
+
 func (v *ctrlHandler) NoFoundHandler(call func(ctx Ctx)) {
 	v.notFound = call
 }
 
-// Match find route in tree
-func (v *ctrlHandler) Match(path string, method string) (int, func(ctx Ctx), uriParamData, []Middleware) {
+// Match find route in tree, the pattern of the matched route is empty if the route is not found
+func (v *ctrlHandler) Match(path string, method string) (int, func(ctx Ctx), uriParamData, []Middleware, string) {
 	uris := urlSplit(path)
 
 	fork := v
@@ -102,18 +124,18 @@ func (v *ctrlHandler) Match(path string, method string) (int, func(ctx Ctx), uri
 			continue
 		}
 		if v.notFound != nil {
-			return http.StatusOK, v.notFound, nil, midd
+			return http.StatusOK, v.notFound, nil, midd, ""
 		}
-		return http.StatusNotFound, nil, nil, v.middlewares
+		return http.StatusNotFound, nil, nil, v.middlewares, ""
 	}
 	if ctrl, ok := fork.methods[method]; ok {
-		return http.StatusOK, ctrl, vr, midd
+		return http.StatusOK, ctrl, vr, midd, fork.pattern
 	}
 	if v.notFound != nil {
-		return http.StatusOK, v.notFound, nil, midd
+		return http.StatusOK, v.notFound, nil, midd, ""
 	}
 	if len(fork.methods) == 0 {
-		return http.StatusNotFound, nil, nil, v.middlewares
+		return http.StatusNotFound, nil, nil, v.middlewares, ""
 	}
-	return http.StatusMethodNotAllowed, nil, nil, v.middlewares
+	return http.StatusMethodNotAllowed, nil, nil, v.middlewares, ""
 }
@@ -17,54 +17,61 @@ func TestUnit_NewHandler(t *testing.T) {
 	h.Route("/aaa/{id}", func(_ Ctx) {}, []string{http.MethodPost})
 	h.Route("", func(_ Ctx) {}, []string{http.MethodPost})
 
-	code, ctrl, vr, midd := h.Match("/aaa/bbb", http.MethodPost)
+	code, ctrl, vr, midd, pattern := h.Match("/aaa/bbb", http.MethodPost)
 	casecheck.Equal(t, 200, code)
 	casecheck.NotNil(t, ctrl)
 	casecheck.Equal(t, 0, len(midd))
 	casecheck.Equal(t, uriParamData{"id": "bbb"}, vr)
+	casecheck.Equal(t, "/aaa/{id}", pattern)
 
 	h.Middlewares("/aaa", RecoveryMiddleware())
 	h.Middlewares("", RecoveryMiddleware())
 
-	code, ctrl, vr, midd = h.Match("/aaa/ccc", http.MethodGet)
+	code, ctrl, vr, midd, pattern = h.Match("/aaa/ccc", http.MethodGet)
 	casecheck.Equal(t, http.StatusMethodNotAllowed, code)
 	casecheck.Nil(t, ctrl)
 	casecheck.Equal(t, 1, len(midd))
 	casecheck.Equal(t, uriParamData(nil), vr)
+	casecheck.Equal(t, "", pattern)
 
-	code, ctrl, vr, midd = h.Match("/aaa/bbb", http.MethodPost)
+	code, ctrl, vr, midd, pattern = h.Match("/aaa/bbb", http.MethodPost)
 	casecheck.Equal(t, http.StatusOK, code)
 	casecheck.NotNil(t, ctrl)
 	casecheck.Equal(t, 2, len(midd))
 	casecheck.Equal(t, uriParamData{"id": "bbb"}, vr)
+	casecheck.Equal(t, "/aaa/{id}", pattern)
 
-	code, ctrl, vr, midd = h.Match("", http.MethodPost)
+	code, ctrl, vr, midd, pattern = h.Match("", http.MethodPost)
 	casecheck.Equal(t, http.StatusOK, code)
 	casecheck.NotNil(t, ctrl)
 	casecheck.Equal(t, 1, len(midd))
 	casecheck.Equal(t, uriParamData{}, vr)
+	casecheck.Equal(t, "/", pattern)
 
 	h.Middlewares("/www/www/www", RecoveryMiddleware())
 
-	code, ctrl, vr, midd = h.Match("/www/www/www", http.MethodPost)
+	code, ctrl, vr, midd, pattern = h.Match("/www/www/www", http.MethodPost)
 	casecheck.Equal(t, http.StatusNotFound, code)
 	casecheck.Nil(t, ctrl)
 	casecheck.Equal(t, 1, len(midd))
 	casecheck.Equal(t, uriParamData(nil), vr)
+	casecheck.Equal(t, "", pattern)
 
-	code, ctrl, vr, midd = h.Match("/test", http.MethodGet)
+	code, ctrl, vr, midd, pattern = h.Match("/test", http.MethodGet)
 	casecheck.Equal(t, http.StatusNotFound, code)
 	casecheck.Nil(t, ctrl)
 	casecheck.Equal(t, 1, len(midd))
 	casecheck.Equal(t, uriParamData(nil), vr)
+	casecheck.Equal(t, "", pattern)
 
 	h.NoFoundHandler(func(_ Ctx) {})
 
-	code, ctrl, vr, midd = h.Match("/test", http.MethodGet)
+	code, ctrl, vr, midd, pattern = h.Match("/test", http.MethodGet)
 	casecheck.Equal(t, http.StatusOK, code)
 	casecheck.NotNil(t, ctrl)
 	casecheck.Equal(t, 1, len(midd))
 	casecheck.Equal(t, uriParamData(nil), vr)
+	casecheck.Equal(t, "", pattern)
 }
 
 func TestUnit_NewHandler2(t *testing.T) {
@@ -73,10 +80,11 @@ func TestUnit_NewHandler2(t *testing.T) {
 
 	h.Middlewares("/api/v{id}", RecoveryMiddleware())
 
-	code, ctrl, vr, midd := h.Match("/api/v1/data/user/aaaa", http.MethodGet)
+	code, ctrl, vr, midd, pattern := h.Match("/api/v1/data/user/aaaa", http.MethodGet)
 	casecheck.Equal(t, http.StatusOK, code)
 	casecheck.NotNil(t, ctrl)
 	casecheck.Equal(t, 1, len(midd))
 	casecheck.Equal(t, uriParamData{"id": "1"}, vr)
+	casecheck.Equal(t, "/api/v{id}/data/#", pattern)
 
 }
@@ -0,0 +1,167 @@
+/*
+ *  Copyright (c) 2022-2026 Mikhail Knyazhev <markus621@yandex.com>. All rights reserved.
+ *  Use of this source code is governed by a BSD 3-Clause license that can be found in the LICENSE file.
+ */
+
+package web
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+	"reflect"
+	"regexp"
+	"runtime"
+	"slices"
+	"strings"
+)
+
+var rexFuncSuffix = regexp.MustCompile(`(\.func\d+)+$`)
+
+// RouteInfo description of the registered route
+type RouteInfo struct {
+	Tag         string   `json:"tag,omitempty"`
+	Name        string   `json:"name,omitempty"`
+	Method      string   `json:"method"`
+	Path        string   `json:"path"`
+	Middlewares []string `json:"middlewares,omitempty"`
+}
+
+// Routes list of the registered routes sorted by path and method
+func (v *BaseRouter) Routes() []RouteInfo {
+	v.mux.RLock()
+	result := slices.Clone(v.routes)
+	for i := range result {
+		for _, m := range v.handler.MiddlewaresOf(result[i].Path) {
+			result[i].Middlewares = append(result[i].Middlewares, middlewareName(m))
+		}
+	}
+	v.mux.RUnlock()
+
+	sortRouteInfo(result)
+
+	return result
+}
+
+// URLFor builds URL of the named route, args are pairs of the param name and value,
+// the args not declared in the route are added to the query, the tail of the `#` route is set by the `#` key
+func (v *BaseRouter) URLFor(name string, args ...any) (string, error) {
+	v.mux.RLock()
+	pattern, ok := v.names[name]
+	v.mux.RUnlock()
+
+	if !ok {
+		return "", fmt.Errorf("route `%s`: %w", name, errRouteNotFound)
+	}
+	if len(args)%2 != 0 {
+		return "", fmt.Errorf("route `%s`: %w: odd number of args", name, errRouteParams)
+	}
+
+	params := make(map[string]string, len(args)/2)
+	for i := 0; i < len(args); i += 2 {
+		params[strings.ToLower(typingJSONKey(args[i]))] = typingJSONKey(args[i+1])
+	}
+
+	uris := urlSplit(pattern)
+	for i, uri := range uris {
+		if uri == anyPath {
+			tail := params[anyPath]
+			delete(params, anyPath)
+			uris[i] = strings.Trim(tail, urlSplitSeparate)
+			continue
+		}
+
+		var err error
+		uris[i] = rexUrlParams.ReplaceAllStringFunc(uri, func(s string) string {
+			match := rexUrlParams.FindStringSubmatch(s)
+			val, ok := params[match[1]]
+			if !ok {
+				err = fmt.Errorf("route `%s`: %w: `%s` is required", name, errRouteParams, match[1])
+				return s
+			}
+			delete(params, match[1])
+			if len(match[2]) > 0 {
+				if ok, _ = regexp.MatchString("^(?:"+match[2]+")$", val); !ok {
+					err = fmt.Errorf("route `%s`: %w: `%s` does not match `%s`", name, errRouteParams, match[1], match[2])
+					return s
+				}
+			}
+			return url.PathEscape(val)
+		})
+		if err != nil {
+			return "", err
+		}
+	}
+
+	result := strings.TrimRight("/"+strings.Join(uris, urlSplitSeparate), urlSplitSeparate)
+	if len(result) == 0 {
+		result = urlSplitSeparate
+	}
+
+	if len(params) > 0 {
+		query := make(url.Values, len(params))
+		for key, val := range params {
+			query.Set(key, val)
+		}
+		result += "?" + query.Encode()
+	}
+
+	return result, nil
+}
+
+func (v *BaseRouter) addRouteInfo(name, path string, methods []string) {
+	path = routePattern(path)
+
+	for _, m := range methods {
+		m = strings.ToUpper(m)
+		i := slices.IndexFunc(v.routes, func(r RouteInfo) bool {
+			return r.Method == m && r.Path == path
+		})
+		if i < 0 {
+			v.routes = append(v.routes, RouteInfo{Name: name, Method: m, Path: path})
+			continue
+		}
+		if len(name) > 0 {
+			v.routes[i].Name = name
+		}
+	}
+}
+
+func routePattern(path string) string {
+	return "/" + strings.Join(urlSplit(path), urlSplitSeparate)
+}
+
+func sortRouteInfo(list []RouteInfo) {
+	slices.SortFunc(list, func(a, b RouteInfo) int {
+		if c := strings.Compare(a.Tag, b.Tag); c != 0 {
+			return c
+		}
+		if c := strings.Compare(a.Path, b.Path); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Method, b.Method)
+	})
+}
+
+func middlewareName(m Middleware) string {
+	fn := runtime.FuncForPC(reflect.ValueOf(m).Pointer())
+	if fn == nil {
+		return "unknown"
+	}
+	return rexFuncSuffix.ReplaceAllString(path.Base(fn.Name()), "")
+}
+
+/**********************************************************************************************************************/
+
+// RouteTableHandler sends the routes of all servers of the pool
+func RouteTableHandler(pool ServerPool) func(ctx Ctx) {
+	return func(ctx Ctx) {
+		result := make([]RouteInfo, 0, 10)
+		pool.All(func(_ string, r Router) {
+			result = append(result, r.Routes()...)
+		})
+		sortRouteInfo(result)
+		ctx.JSON(http.StatusOK, result)
+	}
+}
@@ -0,0 +1,92 @@
+/*
+ *  Copyright (c) 2022-2026 Mikhail Knyazhev <markus621@yandex.com>. All rights reserved.
+ *  Use of this source code is governed by a BSD 3-Clause license that can be found in the LICENSE file.
+ */
+
+package web
+
+import (
+	"bufio"
+	"net"
+	"net/http"
+)
+
+// ResponseStat status and body size of the written response
+type ResponseStat struct {
+	Code int
+	Size int64
+}
+
+// ObserveMiddleware calls begin before the handler and the returned func after it
+// with the status and the body size written by the handler
+func ObserveMiddleware(begin func(ctx Ctx) func(stat ResponseStat)) Middleware {
+	return func(call func(Ctx)) func(Ctx) {
+		return func(ctx Ctx) {
+			end := begin(ctx)
+
+			c, ok := ctx.(*_ctx)
+			if !ok {
+				call(ctx)
+				end(ResponseStat{Code: http.StatusOK})
+				return
+			}
+
+			w := &observeWriter{ResponseWriter: c.w}
+			c.w = w
+			defer func() {
+				c.w = w.ResponseWriter
+				if w.code == 0 {
+					w.code = http.StatusOK
+				}
+				end(ResponseStat{Code: w.code, Size: w.size})
+			}()
+
+			call(ctx)
+		}
+	}
+}
+
+/**********************************************************************************************************************/
+
+type observeWriter struct {
+	http.ResponseWriter
+	code int
+	size int64
+}
+
+func (w *observeWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+func (w *observeWriter) WriteHeader(code int) {
+	if w.code == 0 {
+		w.code = code
+	}
+	w.ResponseWriter.WriteHeader(code)
+}
+
+func (w *observeWriter) Write(b []byte) (int, error) {
+	if w.code == 0 {
+		w.code = http.StatusOK
+	}
+	n, err := w.ResponseWriter.Write(b)
+	w.size += int64(n)
+	return n, err
+}
+
+func (w *observeWriter) Flush() {
+	if w.code == 0 {
+		w.code = http.StatusOK
+	}
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// Hijack keeps websocket upgrades working behind the middleware
+func (w *observeWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	if w.code == 0 {
+		w.code = http.StatusSwitchingProtocols
+	}
+	return http.NewResponseController(w.ResponseWriter).Hijack()
+}
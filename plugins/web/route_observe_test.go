@@ -0,0 +1,50 @@
+/*
+ *  Copyright (c) 2022-2026 Mikhail Knyazhev <markus621@yandex.com>. All rights reserved.
+ *  Use of this source code is governed by a BSD 3-Clause license that can be found in the LICENSE file.
+ */
+
+package web_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"go.osspkg.com/casecheck"
+
+	"go.osspkg.com/goppy/v3/plugins/web"
+)
+
+func TestUnit_ObserveMiddleware(t *testing.T) {
+	type result struct {
+		tag, pattern string
+		stat         web.ResponseStat
+	}
+	var got result
+
+	r := web.NewBaseRouter()
+	r.Tag("main")
+	r.Global(web.ObserveMiddleware(func(ctx web.Ctx) func(web.ResponseStat) {
+		got = result{tag: ctx.ServerTag()}
+		return func(stat web.ResponseStat) {
+			got.pattern, got.stat = ctx.RoutePattern(), stat
+		}
+	}))
+	r.Route("/users/{id}", func(ctx web.Ctx) {
+		id, _ := ctx.Param("id").String() // nolint: errcheck
+		ctx.String(http.StatusCreated, "user %s", id)
+	}, http.MethodPost)
+	r.Route("/empty", func(ctx web.Ctx) {}, http.MethodGet)
+
+	r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/users/123", nil))
+	casecheck.Equal(t, result{tag: "main", pattern: "/users/{id}", stat: web.ResponseStat{Code: 201, Size: 8}}, got)
+
+	r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/empty", nil))
+	casecheck.Equal(t, result{tag: "main", pattern: "/empty", stat: web.ResponseStat{Code: 200}}, got)
+
+	r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/users/123", nil))
+	casecheck.Equal(t, result{tag: "main", pattern: "", stat: web.ResponseStat{Code: 405}}, got)
+
+	r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/unknown", nil))
+	casecheck.Equal(t, result{tag: "main", pattern: "", stat: web.ResponseStat{Code: 404}}, got)
+}
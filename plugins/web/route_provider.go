@@ -24,6 +24,8 @@ type (
 	Router interface {
 		Use(args ...Middleware)
 		NotFoundHandler(call func(ctx Ctx))
+		Routes() []RouteInfo
+		URLFor(name string, args ...any) (string, error)
 		RouteCollector
 	}
 
@@ -37,6 +39,7 @@ type (
 		Options(path string, call func(ctx Ctx))
 		Patch(path string, call func(ctx Ctx))
 		Match(path string, call func(ctx Ctx), methods ...string)
+		Named(name, path string, call func(ctx Ctx), methods ...string)
 		Collection(prefix string, args ...Middleware) RouteCollector
 	}
 )
@@ -70,6 +73,24 @@ func (v *route) NotFoundHandler(call func(ctx Ctx)) {
 	})
 }
 
+func (v *route) Routes() []RouteInfo {
+	result := v.route.Routes()
+	for i := range result {
+		result[i].Tag = v.name
+	}
+	return result
+}
+
+func (v *route) URLFor(name string, args ...any) (string, error) {
+	return v.route.URLFor(name, args...)
+}
+
+func (v *route) Named(name, path string, call func(ctx Ctx), methods ...string) {
+	v.route.NamedRoute(name, path, func(ctx Ctx) {
+		call(ctx)
+	}, methods...)
+}
+
 func (v *route) Match(path string, call func(ctx Ctx), methods ...string) {
 	v.route.Route(path, func(ctx Ctx) {
 		call(ctx)
@@ -107,6 +128,11 @@ func (v *rc) Match(path string, call func(ctx Ctx), methods ...string) {
 	v.r.Match(path, call, methods...)
 }
 
+func (v *rc) Named(name, path string, call func(ctx Ctx), methods ...string) {
+	path = strings.TrimRight(v.p, "/") + "/" + strings.Trim(path, "/")
+	v.r.Named(name, path, call, methods...)
+}
+
 func (v *rc) Get(path string, call func(ctx Ctx))     { v.Match(path, call, http.MethodGet) }
 func (v *rc) Head(path string, call func(ctx Ctx))    { v.Match(path, call, http.MethodHead) }
 func (v *rc) Post(path string, call func(ctx Ctx))    { v.Match(path, call, http.MethodPost) }
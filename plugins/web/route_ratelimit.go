@@ -0,0 +1,205 @@
+/*
+ *  Copyright (c) 2022-2026 Mikhail Knyazhev <markus621@yandex.com>. All rights reserved.
+ *  Use of this source code is governed by a BSD 3-Clause license that can be found in the LICENSE file.
+ */
+
+package web
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"go.osspkg.com/errors"
+	"go.osspkg.com/logx"
+)
+
+type (
+	// RateLimitStore storage of the rate limit state, the implementation can share the state between instances
+	RateLimitStore interface {
+		// Take takes one request from the quota of the key
+		Take(ctx context.Context, key string, limit int64, window time.Duration) (RateLimitResult, error)
+	}
+
+	// RateLimitResult state of the quota after the request
+	RateLimitResult struct {
+		Allowed    bool
+		Limit      int64
+		Remaining  int64
+		Reset      time.Duration
+		RetryAfter time.Duration
+	}
+
+	// RateLimitKey returns the client key of the request, the request is not limited if the key is empty
+	RateLimitKey func(ctx Ctx) string
+
+	// RateLimitConfig setting of the rate limit middleware
+	RateLimitConfig struct {
+		// Limit count of requests per window
+		Limit int64
+		// Window duration of the quota
+		Window time.Duration
+		// Key client key of the request, RateLimitByIP by default
+		Key RateLimitKey
+		// Store state of the quotas, NewRateLimitMemoryStore by default
+		Store RateLimitStore
+	}
+)
+
+// RateLimitMiddleware limits requests per client with a token bucket,
+// sets RateLimit-* headers and Retry-After when the quota is exhausted
+func RateLimitMiddleware(c RateLimitConfig) Middleware {
+	if c.Key == nil {
+		c.Key = RateLimitByIP()
+	}
+	if c.Store == nil {
+		c.Store = NewRateLimitMemoryStore()
+	}
+	c.Limit = max(c.Limit, 1)
+	if c.Window <= 0 {
+		c.Window = time.Second
+	}
+
+	policy := strconv.FormatInt(c.Limit, 10) + ";w=" + strconv.FormatInt(int64(seconds(c.Window)), 10)
+	err := errors.New(http.StatusText(http.StatusTooManyRequests))
+
+	return func(call func(Ctx)) func(Ctx) {
+		return func(ctx Ctx) {
+			key := c.Key(ctx)
+			if len(key) == 0 {
+				call(ctx)
+				return
+			}
+
+			result, e := c.Store.Take(ctx.Context(), key, c.Limit, c.Window)
+			if e != nil {
+				logx.Error("web.RateLimitMiddleware", "err", e, "key", key)
+				call(ctx)
+				return
+			}
+
+			h := ctx.Response().Header()
+			h.Set("RateLimit-Policy", policy)
+			h.Set("RateLimit-Limit", strconv.FormatInt(result.Limit, 10))
+			h.Set("RateLimit-Remaining", strconv.FormatInt(max(result.Remaining, 0), 10))
+			h.Set("RateLimit-Reset", strconv.Itoa(seconds(result.Reset)))
+
+			if !result.Allowed {
+				h.Set("Retry-After", strconv.Itoa(max(seconds(result.RetryAfter), 1)))
+				ctx.Error(http.StatusTooManyRequests, err)
+				return
+			}
+
+			call(ctx)
+		}
+	}
+}
+
+// RateLimitByIP uses IP address of the client as the key, see Ctx.ClientIP
+func RateLimitByIP() RateLimitKey {
+	return func(ctx Ctx) string {
+		if ip := ctx.ClientIP(); ip != nil {
+			return ip.String()
+		}
+		return ""
+	}
+}
+
+// RateLimitByHeader uses value of the request header as the key
+func RateLimitByHeader(name string) RateLimitKey {
+	return func(ctx Ctx) string {
+		return ctx.Header().Get(name)
+	}
+}
+
+// RateLimitByContext uses value of the request context as the key, for example the authenticated user
+func RateLimitByContext(key any) RateLimitKey {
+	return func(ctx Ctx) string {
+		val := ctx.GetContextValue(key)
+		if val == nil {
+			return ""
+		}
+		return typingJSONKey(val)
+	}
+}
+
+func seconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
+
+/**********************************************************************************************************************/
+
+type (
+	rateLimitMemory struct {
+		items map[string]*rateLimitBucket
+		sweep time.Time
+		now   func() time.Time
+		mux   sync.Mutex
+	}
+
+	rateLimitBucket struct {
+		tokens float64
+		last   time.Time
+		window time.Duration
+	}
+)
+
+// NewRateLimitMemoryStore in-memory token bucket store of the single instance
+func NewRateLimitMemoryStore() RateLimitStore {
+	return &rateLimitMemory{
+		items: make(map[string]*rateLimitBucket, 100),
+		now:   time.Now,
+	}
+}
+
+func (v *rateLimitMemory) Take(_ context.Context, key string, limit int64, window time.Duration) (RateLimitResult, error) {
+	v.mux.Lock()
+	defer v.mux.Unlock()
+
+	now := v.now()
+	v.cleanup(now, window)
+
+	capacity := float64(limit)
+	rate := capacity / window.Seconds()
+
+	b, ok := v.items[key]
+	if !ok {
+		b = &rateLimitBucket{tokens: capacity, last: now}
+		v.items[key] = b
+	}
+	b.tokens = min(capacity, b.tokens+now.Sub(b.last).Seconds()*rate)
+	b.last, b.window = now, window
+
+	result := RateLimitResult{Limit: limit}
+	if b.tokens >= 1 {
+		b.tokens--
+		result.Allowed = true
+	} else {
+		result.RetryAfter = rateDuration(1-b.tokens, rate)
+	}
+	result.Remaining = int64(b.tokens)
+	result.Reset = rateDuration(capacity-b.tokens, rate)
+
+	return result, nil
+}
+
+// cleanup removes the full buckets once per window
+func (v *rateLimitMemory) cleanup(now time.Time, window time.Duration) {
+	if now.Sub(v.sweep) < window {
+		return
+	}
+	v.sweep = now
+
+	for key, b := range v.items {
+		if now.Sub(b.last) >= b.window {
+			delete(v.items, key)
+		}
+	}
+}
+
+func rateDuration(tokens, rate float64) time.Duration {
+	return time.Duration(tokens / rate * float64(time.Second))
+}
@@ -0,0 +1,90 @@
+/*
+ *  Copyright (c) 2022-2026 Mikhail Knyazhev <markus621@yandex.com>. All rights reserved.
+ *  Use of this source code is governed by a BSD 3-Clause license that can be found in the LICENSE file.
+ */
+
+package web
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"go.osspkg.com/casecheck"
+)
+
+func TestUnit_RateLimitMemoryStore(t *testing.T) {
+	now := time.Now()
+	store := NewRateLimitMemoryStore().(*rateLimitMemory)
+	store.now = func() time.Time { return now }
+
+	for i := 0; i < 2; i++ {
+		res, err := store.Take(context.TODO(), "a", 2, time.Second)
+		casecheck.NoError(t, err)
+		casecheck.True(t, res.Allowed)
+		casecheck.Equal(t, int64(1-i), res.Remaining)
+	}
+
+	res, err := store.Take(context.TODO(), "a", 2, time.Second)
+	casecheck.NoError(t, err)
+	casecheck.False(t, res.Allowed)
+	casecheck.Equal(t, 500*time.Millisecond, res.RetryAfter)
+	casecheck.Equal(t, time.Second, res.Reset)
+
+	res, err = store.Take(context.TODO(), "b", 2, time.Second)
+	casecheck.NoError(t, err)
+	casecheck.True(t, res.Allowed)
+
+	now = now.Add(500 * time.Millisecond)
+	res, err = store.Take(context.TODO(), "a", 2, time.Second)
+	casecheck.NoError(t, err)
+	casecheck.True(t, res.Allowed)
+	casecheck.Equal(t, int64(0), res.Remaining)
+
+	now = now.Add(2 * time.Second)
+	_, err = store.Take(context.TODO(), "c", 2, time.Second)
+	casecheck.NoError(t, err)
+	casecheck.Equal(t, 1, len(store.items))
+}
+
+func TestUnit_RateLimitMiddleware(t *testing.T) {
+	r := NewBaseRouter()
+	r.Global(RateLimitMiddleware(RateLimitConfig{
+		Limit:  1,
+		Window: time.Minute,
+		Key:    RateLimitByHeader("X-User"),
+	}))
+	r.Route("/", func(ctx Ctx) {
+		ctx.String(http.StatusOK, "ok")
+	}, http.MethodGet)
+
+	send := func(user string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		if len(user) > 0 {
+			req.Header.Set("X-User", user)
+		}
+		r.ServeHTTP(w, req)
+		return w
+	}
+
+	w := send("u1")
+	casecheck.Equal(t, http.StatusOK, w.Code)
+	casecheck.Equal(t, "1;w=60", w.Header().Get("RateLimit-Policy"))
+	casecheck.Equal(t, "1", w.Header().Get("RateLimit-Limit"))
+	casecheck.Equal(t, "0", w.Header().Get("RateLimit-Remaining"))
+	casecheck.Equal(t, "60", w.Header().Get("RateLimit-Reset"))
+
+	w = send("u1")
+	casecheck.Equal(t, http.StatusTooManyRequests, w.Code)
+	casecheck.Equal(t, "60", w.Header().Get("Retry-After"))
+
+	w = send("u2")
+	casecheck.Equal(t, http.StatusOK, w.Code)
+
+	w = send("")
+	casecheck.Equal(t, http.StatusOK, w.Code)
+	casecheck.Equal(t, "", w.Header().Get("RateLimit-Limit"))
+}
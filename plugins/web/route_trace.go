@@ -0,0 +1,49 @@
+/*
+ *  Copyright (c) 2022-2026 Mikhail Knyazhev <markus621@yandex.com>. All rights reserved.
+ *  Use of this source code is governed by a BSD 3-Clause license that can be found in the LICENSE file.
+ */
+
+package web
+
+import (
+	"net/http"
+
+	"go.osspkg.com/errors"
+
+	"go.osspkg.com/goppy/v3/plugins/trace"
+)
+
+// TraceMiddleware starts the server span of the request with the parent from the traceparent header,
+// the span is available to the handler by trace.SpanFromContext(ctx.Context())
+//
+//	router.Use(web.TraceMiddleware())
+func TraceMiddleware() Middleware {
+	return ObserveMiddleware(func(ctx Ctx) func(stat ResponseStat) {
+		r := ctx.Request()
+
+		name := r.Method
+		if route := ctx.RoutePattern(); len(route) > 0 {
+			name += " " + route
+		}
+
+		tctx, span := trace.Start(trace.Extract(r.Context(), r.Header), name, trace.KindServer)
+		if c, ok := ctx.(*_ctx); ok {
+			c.r = r.WithContext(tctx)
+		}
+
+		span.SetAttr("http.request.method", r.Method)
+		span.SetAttr("http.route", ctx.RoutePattern())
+		span.SetAttr("url.path", r.URL.Path)
+		if tag := ctx.ServerTag(); len(tag) > 0 {
+			span.SetAttr("server.tag", tag)
+		}
+
+		return func(stat ResponseStat) {
+			span.SetAttr("http.response.status_code", stat.Code)
+			if stat.Code >= http.StatusInternalServerError {
+				span.SetError(errors.New(http.StatusText(stat.Code)))
+			}
+			span.End()
+		}
+	})
+}
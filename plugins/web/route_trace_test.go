@@ -0,0 +1,48 @@
+/*
+ *  Copyright (c) 2022-2026 Mikhail Knyazhev <markus621@yandex.com>. All rights reserved.
+ *  Use of this source code is governed by a BSD 3-Clause license that can be found in the LICENSE file.
+ */
+
+package web_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"go.osspkg.com/casecheck"
+
+	"go.osspkg.com/goppy/v3/plugins/trace"
+	"go.osspkg.com/goppy/v3/plugins/web"
+)
+
+func TestUnit_TraceMiddleware(t *testing.T) {
+	exp := trace.NewMemoryExporter()
+	trace.SetExporter(exp)
+	defer trace.SetExporter(nil)
+
+	var inner trace.SpanContext
+
+	r := web.NewBaseRouter()
+	r.Tag("main")
+	r.Global(web.TraceMiddleware())
+	r.Route("/users/{id}", func(ctx web.Ctx) {
+		inner = trace.SpanFromContext(ctx.Context()).Context()
+		ctx.String(http.StatusInternalServerError, "fail")
+	}, http.MethodGet)
+
+	req := httptest.NewRequest(http.MethodGet, "/users/1", nil)
+	req.Header.Set(trace.HeaderTraceparent, "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	r.ServeHTTP(httptest.NewRecorder(), req)
+
+	spans := exp.Spans()
+	casecheck.Equal(t, 1, len(spans))
+	casecheck.Equal(t, "GET /users/{id}", spans[0].Name)
+	casecheck.Equal(t, trace.KindServer, spans[0].Kind)
+	casecheck.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", spans[0].TraceID.String())
+	casecheck.Equal(t, "00f067aa0ba902b7", spans[0].ParentSpanID.String())
+	casecheck.Equal(t, spans[0].SpanID, inner.SpanID)
+	casecheck.Equal(t, 500, spans[0].Attrs["http.response.status_code"])
+	casecheck.Equal(t, "main", spans[0].Attrs["server.tag"])
+	casecheck.Equal(t, http.StatusText(500), spans[0].Error)
+}
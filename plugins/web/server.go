@@ -7,10 +7,12 @@ package web
 
 import (
 	"context"
+	"crypto/tls"
 	"fmt"
 	"net"
 	"net/http"
 
+	"github.com/quic-go/quic-go/http3"
 	"go.osspkg.com/errors"
 	"go.osspkg.com/logx"
 	"go.osspkg.com/network/address"
@@ -24,6 +26,7 @@ type (
 	Server struct {
 		conf    Config
 		serv    *http.Server
+		h3      *http3.Server
 		handler http.Handler
 		wg      syncing.Group
 		sync    syncing.Switch
@@ -76,6 +79,13 @@ func (v *Server) Up(ctx xc.Context) error {
 		Handler:      v.handler,
 	}
 
+	if v.conf.H2C {
+		v.serv.Protocols = new(http.Protocols)
+		v.serv.Protocols.SetHTTP1(true)
+		v.serv.Protocols.SetHTTP2(true)
+		v.serv.Protocols.SetUnencryptedHTTP2(true)
+	}
+
 	ln, err := listen.New(ctx.Context(), v.conf.Network, v.conf.Addr, &listen.SSL{Certs: v.conf.Tls})
 	if err != nil {
 		return err
@@ -86,6 +96,13 @@ func (v *Server) Up(ctx xc.Context) error {
 		return fmt.Errorf("http server: does not implement net.Listener for tag '%s'", v.conf.Tag)
 	}
 
+	// the QUIC listener is started after the TCP one and loads the same certificate files
+	if v.conf.HTTP3 {
+		if err = v.upHTTP3(ctx); err != nil {
+			return errors.Wrap(err, nl.Close())
+		}
+	}
+
 	v.wg.Background("http server", func(_ context.Context) {
 		defer ctx.Close()
 
@@ -117,5 +134,65 @@ func (v *Server) Down() error {
 	ctx, cancel := context.WithTimeout(context.Background(), v.conf.ShutdownTimeout)
 	defer cancel()
 
-	return v.serv.Shutdown(ctx)
+	return errors.Wrap(v.serv.Shutdown(ctx), v.downHTTP3(ctx))
+}
+
+func (v *Server) upHTTP3(ctx xc.Context) error {
+	conf, err := tlsConfig(v.conf.Tls)
+	if err != nil {
+		return fmt.Errorf("http3 server: %w", err)
+	}
+
+	conn, err := net.ListenPacket("udp", v.conf.Addr)
+	if err != nil {
+		return fmt.Errorf("http3 server: %w", err)
+	}
+
+	v.h3 = &http3.Server{
+		Addr:        v.conf.Addr,
+		IdleTimeout: v.conf.IdleTimeout,
+		Handler:     v.handler,
+		TLSConfig:   quicTLSConfig(conf),
+	}
+
+	next := v.serv.Handler
+	v.serv.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = v.h3.SetQUICHeaders(w.Header()) //nolint:errcheck
+		next.ServeHTTP(w, r)
+	})
+
+	v.wg.Background("http3 server", func(_ context.Context) {
+		defer ctx.Close()
+
+		logx.Info("HTTP3 Server",
+			"do", "start",
+			"tag", v.conf.Tag,
+			"ip", v.conf.Addr)
+
+		servErr := v.h3.Serve(conn)
+
+		logx.Warn("HTTP3 Server",
+			"do", "stop",
+			"err", servErr,
+			"tag", v.conf.Tag,
+			"ip", v.conf.Addr)
+	})
+	return nil
+}
+
+func (v *Server) downHTTP3(ctx context.Context) error {
+	if v.h3 == nil {
+		return nil
+	}
+	if err := v.h3.Shutdown(ctx); err != nil {
+		return errors.Wrap(err, v.h3.Close())
+	}
+	return nil
+}
+
+// quicTLSConfig the TLS config of the QUIC listener, QUIC requires TLS 1.3
+func quicTLSConfig(conf *tls.Config) *tls.Config {
+	result := conf.Clone()
+	result.MinVersion = tls.VersionTLS13
+	return http3.ConfigureTLSConfig(result)
 }
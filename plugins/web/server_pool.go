@@ -36,7 +36,14 @@ func newServerPool(configs []Config) (*serverPool, error) {
 		if _, ok := v.pool[config.Tag]; ok {
 			return nil, fmt.Errorf("http server pool: duplicate tag: %s", config.Tag)
 		}
-		v.pool[config.Tag] = newRouter(config.Tag, config)
+		r := newRouter(config.Tag, config)
+		if err := r.route.TrustedProxies(config.TrustedProxies...); err != nil {
+			return nil, fmt.Errorf("http server pool: tag '%s': %w", config.Tag, err)
+		}
+		r.route.Tag(config.Tag)
+		r.route.MaxBodySize(config.MaxBodySize)
+		r.route.StrictJSON(config.StrictJSON)
+		v.pool[config.Tag] = r
 	}
 
 	return v, nil
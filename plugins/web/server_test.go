@@ -0,0 +1,127 @@
+/*
+ *  Copyright (c) 2022-2026 Mikhail Knyazhev <markus621@yandex.com>. All rights reserved.
+ *  Use of this source code is governed by a BSD 3-Clause license that can be found in the LICENSE file.
+ */
+
+package web
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"go.osspkg.com/casecheck"
+	"go.osspkg.com/network/address"
+	"go.osspkg.com/network/listen"
+
+	"go.osspkg.com/goppy/v3/pkg/xc"
+)
+
+func TestUnit_Server_H2C(t *testing.T) {
+	addr, err := address.RandomPort("127.0.0.1")
+	casecheck.NoError(t, err)
+
+	r := NewBaseRouter()
+	r.Route("/proto", func(ctx Ctx) {
+		ctx.String(http.StatusOK, ctx.Request().Proto)
+	}, http.MethodGet)
+
+	srv := NewServer(context.Background(), Config{Tag: "h2c", Addr: addr, H2C: true}, r)
+	casecheck.NoError(t, srv.Up(xc.New()))
+	defer func() { casecheck.NoError(t, srv.Down()) }()
+
+	protocols := new(http.Protocols)
+	protocols.SetUnencryptedHTTP2(true)
+	cli := &http.Client{Transport: &http.Transport{Protocols: protocols}}
+
+	resp, err := cli.Get("http://" + addr + "/proto")
+	casecheck.NoError(t, err)
+	defer resp.Body.Close() //nolint:errcheck
+
+	b, err := io.ReadAll(resp.Body)
+	casecheck.NoError(t, err)
+	casecheck.Equal(t, http.StatusOK, resp.StatusCode)
+	casecheck.Equal(t, "HTTP/2.0", string(b))
+}
+
+func TestUnit_Server_HTTP3Config(t *testing.T) {
+	dir := t.TempDir()
+	certFile, keyFile := writeCertificate(t, dir)
+
+	conf, err := tlsConfig([]listen.Certificate{{CertFile: certFile, KeyFile: keyFile, CAFile: certFile}})
+	casecheck.NoError(t, err)
+	casecheck.Equal(t, 1, len(conf.Certificates))
+	casecheck.NotNil(t, conf.ClientCAs)
+	casecheck.Equal(t, tls.VerifyClientCertIfGiven, conf.ClientAuth)
+
+	quic := quicTLSConfig(conf)
+	casecheck.Equal(t, uint16(tls.VersionTLS13), quic.MinVersion)
+	casecheck.Equal(t, conf.ClientCAs, quic.ClientCAs)
+	casecheck.Equal(t, conf.ClientAuth, quic.ClientAuth)
+	casecheck.Equal(t, []string{"h3"}, quic.NextProtos)
+
+	conf, err = tlsConfig([]listen.Certificate{{
+		AutoGenerate: true,
+		Addresses:    []string{"127.0.0.1", "example.com"},
+		CertFile:     filepath.Join(dir, "none.pem"),
+		KeyFile:      filepath.Join(dir, "none.key"),
+	}})
+	casecheck.NoError(t, err)
+	casecheck.Equal(t, 1, len(conf.Certificates))
+	leaf, err := x509.ParseCertificate(conf.Certificates[0].Certificate[0])
+	casecheck.NoError(t, err)
+	casecheck.Equal(t, []string{"example.com"}, leaf.DNSNames)
+	casecheck.Equal(t, "127.0.0.1", leaf.IPAddresses[0].String())
+
+	_, err = tlsConfig([]listen.Certificate{{CertFile: certFile, KeyFile: keyFile, CAFile: keyFile}})
+	casecheck.Error(t, err)
+}
+
+func TestUnit_Server_HTTP3AltSvc(t *testing.T) {
+	certFile, keyFile := writeCertificate(t, t.TempDir())
+
+	addr, err := address.RandomPort("127.0.0.1")
+	casecheck.NoError(t, err)
+
+	r := NewBaseRouter()
+	r.Route("/", func(ctx Ctx) { ctx.String(http.StatusOK, "ok") }, http.MethodGet)
+
+	srv := NewServer(context.Background(), Config{
+		Tag:   "h3",
+		Addr:  addr,
+		HTTP3: true,
+		Tls:   []listen.Certificate{{CertFile: certFile, KeyFile: keyFile}},
+	}, r)
+	casecheck.NoError(t, srv.Up(xc.New()))
+	defer func() { casecheck.NoError(t, srv.Down()) }()
+
+	cli := &http.Client{Transport: &http.Transport{
+		TLSClientConfig:   &tls.Config{InsecureSkipVerify: true}, //nolint:gosec
+		ForceAttemptHTTP2: true,
+	}}
+	resp, err := cli.Get("https://" + addr + "/")
+	casecheck.NoError(t, err)
+	defer resp.Body.Close() //nolint:errcheck
+
+	casecheck.Equal(t, http.StatusOK, resp.StatusCode)
+	casecheck.True(t, strings.Contains(resp.Header.Get("Alt-Svc"), "h3="))
+}
+
+func writeCertificate(t *testing.T, dir string) (certFile, keyFile string) {
+	certFile, keyFile = filepath.Join(dir, "cert.pem"), filepath.Join(dir, "key.pem")
+
+	cert, err := generateCertificate([]string{"127.0.0.1", "localhost"})
+	casecheck.NoError(t, err)
+	key, err := x509.MarshalPKCS8PrivateKey(cert.PrivateKey)
+	casecheck.NoError(t, err)
+	casecheck.NoError(t, os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Certificate[0]}), 0600))
+	casecheck.NoError(t, os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: key}), 0600))
+	return
+}
@@ -0,0 +1,101 @@
+/*
+ *  Copyright (c) 2022-2026 Mikhail Knyazhev <markus621@yandex.com>. All rights reserved.
+ *  Use of this source code is governed by a BSD 3-Clause license that can be found in the LICENSE file.
+ */
+
+package web
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"fmt"
+	"math/big"
+	"net"
+	"os"
+	"time"
+
+	"go.osspkg.com/ioutils/fs"
+	"go.osspkg.com/network/listen"
+)
+
+// tlsConfig builds the TLS config of the QUIC listener, the TCP listener is configured by network/listen:
+// the certificates are loaded from files or generated for the addresses if AutoGenerate is set
+// and the files do not exist, the client certificates are verified by the CA if they are given
+func tlsConfig(certs []listen.Certificate) (*tls.Config, error) {
+	conf := &tls.Config{
+		Certificates: make([]tls.Certificate, 0, len(certs)),
+		MinVersion:   tls.VersionTLS12,
+	}
+
+	for _, c := range certs {
+		if c.AutoGenerate && (!fs.FileExist(c.CertFile) || !fs.FileExist(c.KeyFile)) {
+			cert, err := generateCertificate(c.Addresses)
+			if err != nil {
+				return nil, fmt.Errorf("generate certificate for %v: %w", c.Addresses, err)
+			}
+			conf.Certificates = append(conf.Certificates, cert)
+		} else {
+			cert, err := tls.LoadX509KeyPair(c.CertFile, c.KeyFile)
+			if err != nil {
+				return nil, fmt.Errorf("load certificate '%s': %w", c.CertFile, err)
+			}
+			conf.Certificates = append(conf.Certificates, cert)
+		}
+
+		if len(c.CAFile) == 0 {
+			continue
+		}
+		b, err := os.ReadFile(c.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("load CA '%s': %w", c.CAFile, err)
+		}
+		if conf.ClientCAs == nil {
+			conf.ClientCAs = x509.NewCertPool()
+		}
+		if !conf.ClientCAs.AppendCertsFromPEM(b) {
+			return nil, fmt.Errorf("load CA '%s': no certificates found", c.CAFile)
+		}
+		conf.ClientAuth = tls.VerifyClientCertIfGiven
+	}
+
+	return conf, nil
+}
+
+func generateCertificate(addresses []string) (tls.Certificate, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+
+	tmpl := &x509.Certificate{
+		SerialNumber: serial,
+		Subject:      pkix.Name{Organization: []string{"goppy"}},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().AddDate(1, 0, 0),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	for _, addr := range addresses {
+		if ip := net.ParseIP(addr); ip != nil {
+			tmpl.IPAddresses = append(tmpl.IPAddresses, ip)
+		} else {
+			tmpl.DNSNames = append(tmpl.DNSNames, addr)
+		}
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+
+	return tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key}, nil
+}
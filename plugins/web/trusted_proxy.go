@@ -0,0 +1,119 @@
+/*
+ *  Copyright (c) 2022-2026 Mikhail Knyazhev <markus621@yandex.com>. All rights reserved.
+ *  Use of this source code is governed by a BSD 3-Clause license that can be found in the LICENSE file.
+ */
+
+package web
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+)
+
+const (
+	headerForwarded     = "Forwarded"
+	headerXForwardedFor = "X-Forwarded-For"
+	headerXRealIP       = "X-Real-IP"
+)
+
+// trustedProxies networks of the proxies which forwarding headers are trusted
+type trustedProxies []*net.IPNet
+
+func parseTrustedProxies(list []string) (trustedProxies, error) {
+	result := make(trustedProxies, 0, len(list))
+	for _, item := range list {
+		item = strings.TrimSpace(item)
+		if !strings.Contains(item, "/") {
+			ip := net.ParseIP(item)
+			if ip == nil {
+				return nil, fmt.Errorf("invalid trusted proxy '%s'", item)
+			}
+			bits := 8 * net.IPv6len
+			if ip4 := ip.To4(); ip4 != nil {
+				ip, bits = ip4, 8*net.IPv4len
+			}
+			result = append(result, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+			continue
+		}
+		_, cidr, err := net.ParseCIDR(item)
+		if err != nil {
+			return nil, fmt.Errorf("invalid trusted proxy '%s': %w", item, err)
+		}
+		result = append(result, cidr)
+	}
+	return result, nil
+}
+
+func (v trustedProxies) Contains(ip net.IP) bool {
+	for _, cidr := range v {
+		if cidr.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// ClientIP resolves the client address, the forwarding headers are used only if the request came
+// from the trusted proxy, the peer address is returned if a hop added by the trusted proxies is not valid
+func (v trustedProxies) ClientIP(r *http.Request) net.IP {
+	remote := parseHostIP(r.RemoteAddr)
+	if remote == nil || !v.Contains(remote) {
+		return remote
+	}
+
+	var hops []string
+	switch {
+	case len(r.Header.Values(headerForwarded)) > 0:
+		hops = parseForwarded(r.Header.Values(headerForwarded))
+	case len(r.Header.Values(headerXForwardedFor)) > 0:
+		for _, val := range r.Header.Values(headerXForwardedFor) {
+			hops = append(hops, strings.Split(val, ",")...)
+		}
+	default:
+		if ip := parseHostIP(strings.TrimSpace(r.Header.Get(headerXRealIP))); ip != nil {
+			return ip
+		}
+		return remote
+	}
+
+	result := remote
+	for i := len(hops) - 1; i >= 0; i-- {
+		ip := parseHostIP(strings.TrimSpace(hops[i]))
+		if ip == nil {
+			// the chain behind the unparseable hop cannot be trusted, fall back to the peer address
+			return remote
+		}
+		result = ip
+		if !v.Contains(ip) {
+			break
+		}
+	}
+	return result
+}
+
+// parseForwarded returns values of the `for` parameter of the RFC 7239 Forwarded header
+func parseForwarded(values []string) []string {
+	var result []string
+	for _, value := range values {
+		for _, element := range strings.Split(value, ",") {
+			for _, pair := range strings.Split(element, ";") {
+				key, val, ok := strings.Cut(strings.TrimSpace(pair), "=")
+				if !ok || !strings.EqualFold(key, "for") {
+					continue
+				}
+				result = append(result, strings.Trim(val, `"`))
+			}
+		}
+	}
+	return result
+}
+
+// parseHostIP parses IP from the `ip`, `ip:port`, `[ipv6]` or `[ipv6]:port` value
+func parseHostIP(value string) net.IP {
+	if host, _, err := net.SplitHostPort(value); err == nil {
+		value = host
+	}
+	return net.ParseIP(strings.Trim(value, "[]"))
+}
@@ -0,0 +1,113 @@
+/*
+ *  Copyright (c) 2022-2026 Mikhail Knyazhev <markus621@yandex.com>. All rights reserved.
+ *  Use of this source code is governed by a BSD 3-Clause license that can be found in the LICENSE file.
+ */
+
+package web
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"go.osspkg.com/casecheck"
+)
+
+func TestUnit_TrustedProxies_ClientIP(t *testing.T) {
+	proxies, err := parseTrustedProxies([]string{"10.0.0.0/8", "192.168.1.1", "fd00::/8"})
+	casecheck.NoError(t, err)
+
+	_, err = parseTrustedProxies([]string{"10.0.0.0/33"})
+	casecheck.Error(t, err)
+	_, err = parseTrustedProxies([]string{"proxy"})
+	casecheck.Error(t, err)
+
+	tests := []struct {
+		name    string
+		remote  string
+		headers map[string]string
+		want    string
+	}{
+		{
+			name:    "untrusted remote",
+			remote:  "1.1.1.1:1234",
+			headers: map[string]string{"X-Forwarded-For": "2.2.2.2", "X-Real-IP": "3.3.3.3"},
+			want:    "1.1.1.1",
+		},
+		{
+			name:   "trusted without headers",
+			remote: "10.0.0.1:1234",
+			want:   "10.0.0.1",
+		},
+		{
+			name:    "x-real-ip",
+			remote:  "10.0.0.1:1234",
+			headers: map[string]string{"X-Real-IP": "3.3.3.3"},
+			want:    "3.3.3.3",
+		},
+		{
+			name:    "x-forwarded-for spoofed",
+			remote:  "10.0.0.1:1234",
+			headers: map[string]string{"X-Forwarded-For": "6.6.6.6, 2.2.2.2, 192.168.1.1"},
+			want:    "2.2.2.2",
+		},
+		{
+			name:    "x-forwarded-for all trusted",
+			remote:  "10.0.0.1:1234",
+			headers: map[string]string{"X-Forwarded-For": "10.1.1.1, 10.2.2.2"},
+			want:    "10.1.1.1",
+		},
+		{
+			name:    "x-forwarded-for invalid hop",
+			remote:  "10.0.0.1:1234",
+			headers: map[string]string{"X-Forwarded-For": "2.2.2.2, garbage, 10.2.2.2"},
+			want:    "10.0.0.1",
+		},
+		{
+			name:    "forwarded invalid hop",
+			remote:  "10.0.0.1:1234",
+			headers: map[string]string{"Forwarded": "for=unknown, for=10.2.2.2"},
+			want:    "10.0.0.1",
+		},
+		{
+			name:   "forwarded",
+			remote: "10.0.0.1:1234",
+			headers: map[string]string{
+				"Forwarded":       `for="[2001:db8:cafe::17]:4711";proto=https, for=192.168.1.1;by=10.0.0.1`,
+				"X-Forwarded-For": "2.2.2.2",
+			},
+			want: "2001:db8:cafe::17",
+		},
+		{
+			name:    "forwarded ipv4 with port",
+			remote:  "[fd00::1]:1234",
+			headers: map[string]string{"Forwarded": `For="192.0.2.60:8080"`},
+			want:    "192.0.2.60",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "/", nil)
+			r.RemoteAddr = tt.remote
+			for k, v := range tt.headers {
+				r.Header.Set(k, v)
+			}
+			casecheck.Equal(t, tt.want, proxies.ClientIP(r).String())
+		})
+	}
+}
+
+func TestUnit_Ctx_ClientIP(t *testing.T) {
+	r := NewBaseRouter()
+	casecheck.NoError(t, r.TrustedProxies("127.0.0.1"))
+	r.Route("/", func(ctx Ctx) {
+		ctx.String(http.StatusOK, ctx.ClientIP().String())
+	}, http.MethodGet)
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.RemoteAddr = "127.0.0.1:1234"
+	req.Header.Set("X-Forwarded-For", "2.2.2.2")
+	r.ServeHTTP(w, req)
+	casecheck.Equal(t, "2.2.2.2", w.Body.String())
+}
@@ -0,0 +1,102 @@
+/*
+ *  Copyright (c) 2022-2026 Mikhail Knyazhev <markus621@yandex.com>. All rights reserved.
+ *  Use of this source code is governed by a BSD 3-Clause license that can be found in the LICENSE file.
+ */
+
+package ws
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+
+	"go.osspkg.com/errors"
+
+	"go.osspkg.com/goppy/v3/plugins/ws/event"
+	"go.osspkg.com/goppy/v3/plugins/ws/internal"
+)
+
+var (
+	errUnknownConnect = errors.New("unknown connect id")
+	errConnectClosed  = errors.New("connect closed")
+	errSendQueueFull  = errors.New("write chan is full")
+)
+
+// Call sending the event with the correlation id and waiting for the response with the same id,
+// without the deadline in the context the call is limited by internal.CallTimeout,
+// out can be nil if only the error of the response is needed
+func (v *connect) Call(ctx context.Context, eventId event.Id, in, out any) error {
+	cid := rand.Text()
+
+	ev := event.Pool.Get()
+	defer event.Pool.Put(ev)
+
+	ev.WithCodec(v.codec)
+	ev.WithID(eventId)
+	ev.WithCorrelationID(cid)
+	if err := ev.Encode(in); err != nil {
+		return fmt.Errorf("encode message for id '%d': %w", eventId, err)
+	}
+
+	b, err := ev.Marshal()
+	if err != nil {
+		return fmt.Errorf("encode event for id '%d': %w", eventId, err)
+	}
+
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, internal.CallTimeout)
+		defer cancel()
+	}
+
+	resultC := make(chan []byte, 1)
+	v.calls.Set(cid, resultC)
+	defer v.calls.Del(cid)
+
+	if !v.sendRawMessage(b) {
+		return errSendQueueFull
+	}
+
+	select {
+	case <-ctx.Done():
+		return fmt.Errorf("call event '%d': %w", eventId, ctx.Err())
+	case <-v.Done():
+		return errConnectClosed
+	case resp := <-resultC:
+		ev.Reset()
+		ev.WithCodec(v.codec)
+		if err = ev.Unmarshal(resp); err != nil {
+			return fmt.Errorf("decode response for id '%d': %w", eventId, err)
+		}
+		return ev.Decode(out)
+	}
+}
+
+// resolveCall passes the response to the waiting Call, returns false if the event is not a response
+func (v *connect) resolveCall(cid string, b []byte) bool {
+	if len(cid) == 0 {
+		return false
+	}
+	resultC, ok := v.calls.Extract(cid)
+	if !ok {
+		return false
+	}
+	resultC <- b
+	return true
+}
+
+func (v *_server) Call(ctx context.Context, clientId string, eventId event.Id, in, out any) error {
+	conn, ok := v.clients.Get(clientId)
+	if !ok {
+		return fmt.Errorf("%w: %s", errUnknownConnect, clientId)
+	}
+	return conn.Call(ctx, eventId, in, out)
+}
+
+func (v *_client) Call(ctx context.Context, serverId string, eventId event.Id, in, out any) error {
+	conn, ok := v.servers.Get(serverId)
+	if !ok {
+		return fmt.Errorf("%w: %s", errUnknownConnect, serverId)
+	}
+	return conn.Call(ctx, eventId, in, out)
+}
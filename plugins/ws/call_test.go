@@ -0,0 +1,85 @@
+/*
+ *  Copyright (c) 2022-2026 Mikhail Knyazhev <markus621@yandex.com>. All rights reserved.
+ *  Use of this source code is governed by a BSD 3-Clause license that can be found in the LICENSE file.
+ */
+
+package ws
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"go.osspkg.com/casecheck"
+	"go.osspkg.com/errors"
+
+	"go.osspkg.com/goppy/v3/plugins/ws/event"
+)
+
+func TestUnit_Call(t *testing.T) {
+	for _, codec := range []event.Codec{event.JSON, event.Protobuf} {
+		t.Run(codec.Name(), func(t *testing.T) {
+			testCall(t, codec)
+		})
+	}
+}
+
+func testCall(t *testing.T, codec event.Codec) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	type message struct {
+		Value int `json:"v"`
+	}
+	double := func(ev event.Event, _ Meta) error {
+		var m message
+		if err := ev.Decode(&m); err != nil {
+			return err
+		}
+		if m.Value < 0 {
+			return errors.New("negative value")
+		}
+		m.Value *= 2
+		return ev.Encode(&m)
+	}
+
+	srv := NewServerWithOptions(ctx, Codecs(codec))
+	srv.SetEventHandler(double, 1)
+	clientIdC := make(chan string, 1)
+	srv.AddOnOpenFunc(func(cid string) { clientIdC <- cid })
+
+	ts := httptest.NewServer(http.HandlerFunc(srv.HandlingHTTP))
+	defer ts.Close()
+
+	cli := NewClient(ctx)
+	cli.SetEventHandler(double, 1)
+	serverId, err := cli.OpenWithCodecs("ws"+strings.TrimPrefix(ts.URL, "http"), []event.Codec{codec})
+	casecheck.NoError(t, err)
+	clientId := <-clientIdC
+
+	for cli.CountConn() == 0 {
+		time.Sleep(10 * time.Millisecond)
+	}
+	conn, ok := cli.(*_client).servers.Get(serverId)
+	casecheck.True(t, ok)
+	casecheck.Equal(t, codec, conn.codec)
+
+	out := message{}
+	casecheck.NoError(t, cli.Call(ctx, serverId, 1, &message{Value: 2}, &out))
+	casecheck.Equal(t, 4, out.Value)
+
+	err = cli.Call(ctx, serverId, 1, &message{Value: -1}, &out)
+	casecheck.Error(t, err)
+	casecheck.Equal(t, "negative value", err.Error())
+
+	casecheck.NoError(t, srv.Call(ctx, clientId, 1, &message{Value: 5}, &out))
+	casecheck.Equal(t, 10, out.Value)
+
+	casecheck.Error(t, srv.Call(ctx, "unknown", 1, &message{}, nil))
+
+	cli.CloseAll()
+	srv.CloseAll()
+}
@@ -7,13 +7,12 @@ package ws
 
 import (
 	"context"
-	"encoding/json"
-	"fmt"
 	"net/http"
 	"time"
 
 	"github.com/gorilla/websocket"
 	"go.osspkg.com/do"
+	"go.osspkg.com/errors"
 	"go.osspkg.com/logx"
 	"go.osspkg.com/syncing"
 
@@ -22,7 +21,10 @@ import (
 
 type _client struct {
 	events     *syncing.Map[event.Id, EventHandler]
+	conf       *connConfig
 	servers    *syncing.Map[string, *connect]
+	links      *syncing.Map[string, *link]
+	reconnect  *ReconnectPolicy
 	ctx        context.Context
 	cancel     context.CancelFunc
 	openFuncs  *syncing.Slice[func(cid string)]
@@ -30,17 +32,25 @@ type _client struct {
 	wg         syncing.Group
 }
 
-func NewClient(ctx context.Context) Client {
+func NewClient(ctx context.Context, opts ...ClientOption) Client {
 	ctx, cancel := context.WithCancel(ctx)
-	return &_client{
+	cli := &_client{
 		events:     syncing.NewMap[event.Id, EventHandler](10),
+		conf:       newConnConfig(),
 		servers:    syncing.NewMap[string, *connect](10),
+		links:      syncing.NewMap[string, *link](10),
 		ctx:        ctx,
 		cancel:     cancel,
 		openFuncs:  syncing.NewSlice[func(cid string)](2),
 		closeFuncs: syncing.NewSlice[func(cid string)](2),
 		wg:         syncing.NewGroup(ctx),
 	}
+
+	for _, opt := range opts {
+		opt(cli)
+	}
+
+	return cli
 }
 
 func (v *_client) Up() error {
@@ -53,6 +63,14 @@ func (v *_client) Down() error {
 }
 
 func (v *_client) Open(url string, opts ...func(h http.Header, d *websocket.Dialer)) (string, error) {
+	return v.OpenWithCodecs(url, nil, opts...)
+}
+
+// OpenWithCodecs offers the codecs to the server by Sec-WebSocket-Protocol in the preference order,
+// event.JSON is used if the server does not choose any of them
+func (v *_client) OpenWithCodecs(
+	url string, codecs []event.Codec, opts ...func(h http.Header, d *websocket.Dialer),
+) (string, error) {
 	headers := make(http.Header)
 	dial := &websocket.Dialer{
 		Proxy:            http.ProxyFromEnvironment,
@@ -62,6 +80,9 @@ func (v *_client) Open(url string, opts ...func(h http.Header, d *websocket.Dial
 	for _, opt := range opts {
 		opt(headers, dial)
 	}
+	for _, c := range codecs {
+		dial.Subprotocols = append(dial.Subprotocols, c.Name())
+	}
 
 	conn, resp, err := dial.DialContext(v.ctx, url, headers)
 	if err != nil {
@@ -69,24 +90,65 @@ func (v *_client) Open(url string, opts ...func(h http.Header, d *websocket.Dial
 		return "", err
 	}
 
-	clientId := resp.Header.Get("Sec-WebSocket-Accept")
+	codec, err := resolveCodec(conn.Subprotocol(), codecs)
+	if err != nil {
+		logx.Error("WS Client", "do", "open connect", "err", err, "url", url)
+		return "", errors.Wrap(err, conn.Close())
+	}
+
+	serverId := resp.Header.Get("Sec-WebSocket-Accept")
+	l := &link{
+		url:     url,
+		headers: headers,
+		dial:    dial,
+		policy:  v.reconnect,
+		codec:   codec,
+	}
+	v.links.Set(serverId, l)
 
 	v.wg.Background("open connect", func(ctx context.Context) {
-		defer func() {
-			if err := resp.Body.Close(); err != nil {
-				logx.Error("WS Client", "do", "close connect body", "err", err, "url", url)
+		defer v.links.Del(serverId)
+
+		for {
+			v.runConn(serverId, l, conn, resp, codec)
+
+			if l.policy == nil || l.stopped.Load() || ctx.Err() != nil {
 				return
 			}
-		}()
-
-		c := newConnect(v.ctx, clientId, resp.Header, v, conn)
 
-		c.AddOnCloseFunc(func(cid string) { v.delConn(cid) })
-		c.AddOnOpenFunc(func(string) { v.addConn(c) })
-		c.Run()
+			var ok bool
+			if conn, resp, ok = v.redial(ctx, serverId, l); !ok {
+				return
+			}
+			if codec, err = resolveCodec(conn.Subprotocol(), codecs); err != nil {
+				logx.Error("WS Client", "do", "reconnect", "err", err, "url", url, "serverId", serverId)
+				if err = conn.Close(); err != nil {
+					logx.Error("WS Client", "do", "close connect", "err", err, "url", url, "serverId", serverId)
+				}
+				return
+			}
+		}
 	})
 
-	return clientId, nil
+	return serverId, nil
+}
+
+func (v *_client) runConn(serverId string, l *link, conn *websocket.Conn, resp *http.Response, codec event.Codec) {
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			logx.Error("WS Client", "do", "close connect body", "err", err, "url", l.url)
+		}
+	}()
+
+	l.setCodec(codec)
+
+	c := newConnect(v.ctx, serverId, resp.Header, v, conn, codec, v.conf)
+	c.AddOnCloseFunc(func(cid string) { v.delConn(cid) })
+	c.AddOnOpenFunc(func(string) {
+		v.addConn(c)
+		l.flush(c)
+	})
+	c.Run()
 }
 
 func (v *_client) GetEventHandler(eventId event.Id) (EventHandler, bool) {
@@ -110,6 +172,11 @@ func (v *_client) CountConn() int {
 	return v.servers.Size()
 }
 
+// Stats getting the counters of the messages which are not delivered
+func (v *_client) Stats() Stats {
+	return v.conf.counters.Stats()
+}
+
 func (v *_client) addConn(conn *connect) {
 	v.servers.Set(conn.ConnectID(), conn)
 
@@ -141,6 +208,10 @@ func (v *_client) AddOnOpenFunc(callback func(serverId string)) {
 }
 
 func (v *_client) CloseConnect(serverId string) {
+	if l, ok := v.links.Get(serverId); ok {
+		l.stopped.Store(true)
+	}
+
 	conn, ok := v.servers.Extract(serverId)
 	if !ok {
 		return
@@ -155,53 +226,40 @@ func (v *_client) CloseAll() {
 }
 
 func (v *_client) BroadcastEvent(eventId event.Id, message any) error {
-	ev := event.Pool.Get()
-	defer event.Pool.Put(ev)
-
-	ev.WithID(eventId)
-	if err := ev.Encode(message); err != nil {
-		logx.Error("WS Client", "do", "broadcast event", "err", err, "eventId", eventId)
-		return fmt.Errorf("encode message for id '%d': %w", eventId, err)
+	conns := make([]*connect, 0, v.servers.Size())
+	for _, conn := range v.servers.Yield() {
+		conns = append(conns, conn)
 	}
 
-	b, err := json.Marshal(ev)
-	if err != nil {
+	if err := sendEvent(conns, eventEncoder(eventId, message)); err != nil {
 		logx.Error("WS Client", "do", "broadcast event", "err", err, "eventId", eventId)
-		return fmt.Errorf("encode event for id '%d': %w", eventId, err)
+		return err
 	}
 
-	go func() {
-		for _, conn := range v.servers.Yield() {
-			conn.SendRawMessage(b)
-		}
-	}()
-
 	return nil
 }
 
 func (v *_client) SendEvent(eventId event.Id, message any, serverIDs ...string) error {
-	ev := event.Pool.Get()
-	defer event.Pool.Put(ev)
-
-	ev.WithID(eventId)
-	if err := ev.Encode(message); err != nil {
-		logx.Error("WS Client", "do", "send event", "err", err, "eventId", eventId)
-		return fmt.Errorf("encode message for id '%d': %w", eventId, err)
+	conns := make([]*connect, 0, len(serverIDs))
+	for _, id := range serverIDs {
+		if conn, ok := v.servers.Get(id); ok {
+			conns = append(conns, conn)
+			continue
+		}
+		if l, ok := v.links.Get(id); ok && l.buffering() {
+			b, err := encodeEvent(l.Codec(), eventId, message)
+			if err != nil {
+				logx.Error("WS Client", "do", "send event", "err", err, "eventId", eventId)
+				return err
+			}
+			l.push(b, v.conf.counters)
+		}
 	}
 
-	b, err := json.Marshal(ev)
-	if err != nil {
+	if err := sendEvent(conns, eventEncoder(eventId, message)); err != nil {
 		logx.Error("WS Client", "do", "send event", "err", err, "eventId", eventId)
-		return fmt.Errorf("encode event for id '%d': %w", eventId, err)
+		return err
 	}
 
-	go func() {
-		for _, clientId := range serverIDs {
-			if conn, ok := v.servers.Get(clientId); ok {
-				conn.SendRawMessage(b)
-			}
-		}
-	}()
-
 	return nil
 }
@@ -0,0 +1,149 @@
+/*
+ *  Copyright (c) 2022-2026 Mikhail Knyazhev <markus621@yandex.com>. All rights reserved.
+ *  Use of this source code is governed by a BSD 3-Clause license that can be found in the LICENSE file.
+ */
+
+package ws
+
+import (
+	"context"
+	"math"
+	"math/rand/v2"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"go.osspkg.com/logx"
+
+	"go.osspkg.com/goppy/v3/plugins/ws/event"
+)
+
+type (
+	// ClientOption of the websocket client
+	ClientOption func(c *_client)
+
+	// ReconnectPolicy of the dropped server connections, the logical server id stays the same
+	// and the open funcs are called again after every reconnect
+	ReconnectPolicy struct {
+		// MinDelay first delay before reconnect, default 500ms
+		MinDelay time.Duration
+		// MaxDelay limit of the exponential delay, default 30s
+		MaxDelay time.Duration
+		// Multiplier of the delay for the next attempt, default 2
+		Multiplier float64
+		// Jitter randomizes the delay in the range of ±Jitter*delay, from 0 to 1
+		Jitter float64
+		// MaxAttempts in a row before the connection is closed, zero is unlimited
+		MaxAttempts int
+		// BufferSize of the SendEvent messages kept while the link is down, zero disables buffering
+		BufferSize int
+	}
+
+	link struct {
+		url     string
+		headers http.Header
+		dial    *websocket.Dialer
+		policy  *ReconnectPolicy
+		stopped atomic.Bool
+
+		pending [][]byte
+		codec   event.Codec
+		mux     sync.Mutex
+	}
+)
+
+// Reconnect enables the reconnection of the dropped server connections
+func Reconnect(policy ReconnectPolicy) ClientOption {
+	return func(c *_client) {
+		if policy.MinDelay <= 0 {
+			policy.MinDelay = 500 * time.Millisecond
+		}
+		if policy.MaxDelay < policy.MinDelay {
+			policy.MaxDelay = max(30*time.Second, policy.MinDelay)
+		}
+		if policy.Multiplier < 1 {
+			policy.Multiplier = 2
+		}
+		policy.Jitter = min(max(policy.Jitter, 0), 1)
+		c.reconnect = &policy
+	}
+}
+
+// Delay getting the delay before the attempt which starts from zero
+func (v *ReconnectPolicy) Delay(attempt int) time.Duration {
+	delay := float64(v.MinDelay) * math.Pow(v.Multiplier, float64(attempt))
+	delay = min(delay, float64(v.MaxDelay))
+	if v.Jitter > 0 {
+		delay += delay * v.Jitter * (rand.Float64()*2 - 1)
+	}
+	return time.Duration(delay)
+}
+
+/**********************************************************************************************************************/
+
+func (v *link) Codec() event.Codec {
+	v.mux.Lock()
+	defer v.mux.Unlock()
+	return v.codec
+}
+
+func (v *link) setCodec(c event.Codec) {
+	v.mux.Lock()
+	v.codec = c
+	v.mux.Unlock()
+}
+
+func (v *link) buffering() bool {
+	return v.policy != nil && v.policy.BufferSize > 0 && !v.stopped.Load()
+}
+
+// push keeps the message until the reconnect, the oldest messages are dropped on overflow
+func (v *link) push(b []byte, c *counters) {
+	v.mux.Lock()
+	defer v.mux.Unlock()
+
+	if len(v.pending) >= v.policy.BufferSize {
+		v.pending = v.pending[1:]
+		c.dropped.Add(1)
+	}
+	v.pending = append(v.pending, b)
+}
+
+func (v *link) flush(conn *connect) {
+	v.mux.Lock()
+	pending := v.pending
+	v.pending = nil
+	v.mux.Unlock()
+
+	for _, b := range pending {
+		conn.SendRawMessage(b)
+	}
+}
+
+// redial connecting again with the backoff until the success, the limit of attempts or the stop
+func (v *_client) redial(ctx context.Context, id string, l *link) (*websocket.Conn, *http.Response, bool) {
+	for attempt := 0; l.policy.MaxAttempts <= 0 || attempt < l.policy.MaxAttempts; attempt++ {
+		timer := time.NewTimer(l.policy.Delay(attempt))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, nil, false
+		case <-timer.C:
+		}
+
+		if l.stopped.Load() {
+			return nil, nil, false
+		}
+
+		conn, resp, err := l.dial.DialContext(ctx, l.url, l.headers)
+		if err == nil {
+			return conn, resp, true
+		}
+		logx.Warn("WS Client", "do", "reconnect", "err", err, "url", l.url, "serverId", id, "attempt", attempt+1)
+	}
+
+	logx.Error("WS Client", "do", "reconnect", "err", "attempts are over", "url", l.url, "serverId", id)
+	return nil, nil, false
+}
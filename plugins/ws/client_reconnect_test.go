@@ -0,0 +1,93 @@
+/*
+ *  Copyright (c) 2022-2026 Mikhail Knyazhev <markus621@yandex.com>. All rights reserved.
+ *  Use of this source code is governed by a BSD 3-Clause license that can be found in the LICENSE file.
+ */
+
+package ws
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"go.osspkg.com/casecheck"
+
+	"go.osspkg.com/goppy/v3/plugins/ws/event"
+)
+
+func TestUnit_ReconnectPolicy(t *testing.T) {
+	c := &_client{}
+	Reconnect(ReconnectPolicy{MinDelay: time.Second, MaxDelay: 5 * time.Second})(c)
+	casecheck.Equal(t, time.Second, c.reconnect.Delay(0))
+	casecheck.Equal(t, 2*time.Second, c.reconnect.Delay(1))
+	casecheck.Equal(t, 4*time.Second, c.reconnect.Delay(2))
+	casecheck.Equal(t, 5*time.Second, c.reconnect.Delay(3))
+
+	Reconnect(ReconnectPolicy{MinDelay: time.Second, Jitter: 0.5})(c)
+	for i := 0; i < 100; i++ {
+		d := c.reconnect.Delay(0)
+		casecheck.True(t, d >= 500*time.Millisecond && d <= 1500*time.Millisecond)
+	}
+}
+
+func TestUnit_ClientReconnect(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	srv := NewServer(ctx)
+	clientIdC := make(chan string, 4)
+	srv.AddOnOpenFunc(func(cid string) { clientIdC <- cid })
+	receiveC := make(chan string, 4)
+	srv.SetEventHandler(func(ev event.Event, _ Meta) error {
+		var msg string
+		if err := ev.Decode(&msg); err != nil {
+			return err
+		}
+		receiveC <- msg
+		return nil
+	}, 1)
+
+	var reject atomic.Bool
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if reject.Load() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		srv.HandlingHTTP(w, r)
+	}))
+	defer ts.Close()
+
+	cli := NewClient(ctx, Reconnect(ReconnectPolicy{MinDelay: 10 * time.Millisecond, BufferSize: 10}))
+	openC := make(chan string, 4)
+	cli.AddOnOpenFunc(func(sid string) { openC <- sid })
+
+	serverId, err := cli.Open("ws" + strings.TrimPrefix(ts.URL, "http"))
+	casecheck.NoError(t, err)
+	casecheck.Equal(t, serverId, <-openC)
+
+	reject.Store(true)
+	srv.CloseOne(<-clientIdC)
+	for cli.CountConn() != 0 {
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	casecheck.NoError(t, cli.SendEvent(1, "hello", serverId))
+	reject.Store(false)
+
+	casecheck.Equal(t, serverId, <-openC)
+	casecheck.Equal(t, "hello", <-receiveC)
+
+	cli.CloseConnect(serverId)
+	for cli.CountConn() != 0 {
+		time.Sleep(10 * time.Millisecond)
+	}
+	time.Sleep(50 * time.Millisecond)
+	casecheck.Equal(t, 0, cli.CountConn())
+
+	cli.CloseAll()
+	srv.CloseAll()
+}
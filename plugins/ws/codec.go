@@ -0,0 +1,111 @@
+/*
+ *  Copyright (c) 2022-2026 Mikhail Knyazhev <markus621@yandex.com>. All rights reserved.
+ *  Use of this source code is governed by a BSD 3-Clause license that can be found in the LICENSE file.
+ */
+
+package ws
+
+import (
+	"fmt"
+
+	"go.osspkg.com/goppy/v3/plugins/ws/event"
+)
+
+// Codecs enables the negotiation of the codecs by Sec-WebSocket-Protocol in the preference order,
+// clients without the subprotocol use event.JSON. The codecs are kept by the server
+// and do not change the codecs of other servers and clients.
+func Codecs(codecs ...event.Codec) Option {
+	return func(o *options) {
+		for _, c := range codecs {
+			o.codecs = append(o.codecs, c)
+			o.upgrade.Subprotocols = append(o.upgrade.Subprotocols, c.Name())
+		}
+	}
+}
+
+// resolveCodec getting the codec of the negotiated subprotocol from the codecs of the instance,
+// event.JSON is used without the subprotocol
+func resolveCodec(subprotocol string, codecs []event.Codec) (event.Codec, error) {
+	if len(subprotocol) == 0 {
+		return event.JSON, nil
+	}
+	for _, c := range codecs {
+		if c.Name() == subprotocol {
+			return c, nil
+		}
+	}
+	return nil, fmt.Errorf("unsupported subprotocol '%s'", subprotocol)
+}
+
+// eventEncoder encoding the message by the codec of the connection
+func eventEncoder(eventId event.Id, message any) func(c event.Codec) ([]byte, error) {
+	return func(c event.Codec) ([]byte, error) {
+		return encodeEvent(c, eventId, message)
+	}
+}
+
+// payloadEncoder wrapping the payload already encoded by the codec of the connection
+func payloadEncoder(eventId event.Id, payloads map[string][]byte) func(c event.Codec) ([]byte, error) {
+	return func(c event.Codec) ([]byte, error) {
+		data, ok := payloads[c.Name()]
+		if !ok {
+			return nil, fmt.Errorf("no payload for codec '%s' of id '%d'", c.Name(), eventId)
+		}
+
+		ev := event.Pool.Get()
+		defer event.Pool.Put(ev)
+
+		ev.WithCodec(c)
+		ev.WithID(eventId)
+		ev.Data = data
+
+		b, err := ev.Marshal()
+		if err != nil {
+			return nil, fmt.Errorf("encode event for id '%d': %w", eventId, err)
+		}
+		return b, nil
+	}
+}
+
+func encodeEvent(c event.Codec, eventId event.Id, message any) ([]byte, error) {
+	ev := event.Pool.Get()
+	defer event.Pool.Put(ev)
+
+	ev.WithCodec(c)
+	ev.WithID(eventId)
+	if err := ev.Encode(message); err != nil {
+		return nil, fmt.Errorf("encode message for id '%d': %w", eventId, err)
+	}
+
+	b, err := ev.Marshal()
+	if err != nil {
+		return nil, fmt.Errorf("encode event for id '%d': %w", eventId, err)
+	}
+	return b, nil
+}
+
+// sendEvent encoding the event once per codec of the connections and sending it in background
+func sendEvent(conns []*connect, encode func(c event.Codec) ([]byte, error)) error {
+	cache := make(map[string][]byte, 2)
+	list := make([][]byte, 0, len(conns))
+
+	for _, conn := range conns {
+		b, ok := cache[conn.codec.Name()]
+		if !ok {
+			var err error
+			if b, err = encode(conn.codec); err != nil {
+				return err
+			}
+			cache[conn.codec.Name()] = b
+		}
+		list = append(list, b)
+	}
+
+	go func() {
+		for i, conn := range conns {
+			conn.SendRawMessage(list[i])
+		}
+	}()
+
+	return nil
+}
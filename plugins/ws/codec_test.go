@@ -0,0 +1,66 @@
+/*
+ *  Copyright (c) 2022-2026 Mikhail Knyazhev <markus621@yandex.com>. All rights reserved.
+ *  Use of this source code is governed by a BSD 3-Clause license that can be found in the LICENSE file.
+ */
+
+package ws
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"go.osspkg.com/casecheck"
+
+	"go.osspkg.com/goppy/v3/plugins/ws/event"
+)
+
+type namedCodec struct {
+	event.Codec
+	name string
+}
+
+func (v namedCodec) Name() string { return v.name }
+
+func TestUnit_CodecsPerInstance(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	custom := namedCodec{Codec: event.JSON, name: "test.custom"}
+
+	srvA := NewServerWithOptions(ctx, Codecs(custom))
+	srvB := NewServer(ctx)
+	tsA := httptest.NewServer(http.HandlerFunc(srvA.HandlingHTTP))
+	defer tsA.Close()
+	tsB := httptest.NewServer(http.HandlerFunc(srvB.HandlingHTTP))
+	defer tsB.Close()
+
+	cli := NewClient(ctx)
+	idA, err := cli.OpenWithCodecs("ws"+strings.TrimPrefix(tsA.URL, "http"), []event.Codec{custom})
+	casecheck.NoError(t, err)
+	idB, err := cli.OpenWithCodecs("ws"+strings.TrimPrefix(tsB.URL, "http"), []event.Codec{custom})
+	casecheck.NoError(t, err)
+	for cli.CountConn() < 2 {
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	connA, ok := cli.(*_client).servers.Get(idA)
+	casecheck.True(t, ok)
+	casecheck.Equal(t, custom.Name(), connA.codec.Name())
+	connB, ok := cli.(*_client).servers.Get(idB)
+	casecheck.True(t, ok)
+	casecheck.Equal(t, event.JSON.Name(), connB.codec.Name())
+
+	_, err = resolveCodec(custom.Name(), []event.Codec{event.JSON})
+	casecheck.Error(t, err)
+	c, err := resolveCodec("", nil)
+	casecheck.NoError(t, err)
+	casecheck.Equal(t, event.JSON, c)
+
+	cli.CloseAll()
+	srvA.CloseAll()
+	srvB.CloseAll()
+}
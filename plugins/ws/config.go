@@ -0,0 +1,31 @@
+/*
+ *  Copyright (c) 2022-2026 Mikhail Knyazhev <markus621@yandex.com>. All rights reserved.
+ *  Use of this source code is governed by a BSD 3-Clause license that can be found in the LICENSE file.
+ */
+
+package ws
+
+import "fmt"
+
+type (
+	ConfigGroup struct {
+		WS Config `yaml:"websocket"`
+	}
+	Config struct {
+		// AllowedOrigins origins which can open the connection, the empty list allows any origin
+		AllowedOrigins []string `yaml:"allowed_origins,omitempty"`
+	}
+)
+
+func (v *ConfigGroup) Default() {
+	v.WS.AllowedOrigins = []string{"*"}
+}
+
+func (v *ConfigGroup) Validate() error {
+	for _, origin := range v.WS.AllowedOrigins {
+		if err := validateOrigin(origin); err != nil {
+			return fmt.Errorf("websocket server: %w", err)
+		}
+	}
+	return nil
+}
@@ -7,14 +7,16 @@ package ws
 
 import (
 	"context"
-	"encoding/json"
 	"net/http"
+	"strconv"
+	"time"
 
 	"github.com/gorilla/websocket"
 	"go.osspkg.com/do"
 	"go.osspkg.com/logx"
 	"go.osspkg.com/syncing"
 
+	"go.osspkg.com/goppy/v3/plugins/trace"
 	"go.osspkg.com/goppy/v3/plugins/ws/event"
 	"go.osspkg.com/goppy/v3/plugins/ws/internal"
 )
@@ -27,9 +29,13 @@ type (
 	connect struct {
 		id         string
 		header     http.Header
+		principal  any
 		resolver   eventResolver
 		conn       *websocket.Conn
+		codec      event.Codec
+		conf       *connConfig
 		dataC      chan []byte
+		calls      *syncing.Map[string, chan []byte]
 		ctx        context.Context
 		cancel     context.CancelFunc
 		openFuncs  *syncing.Slice[func(cid string)]
@@ -38,14 +44,20 @@ type (
 	}
 )
 
-func newConnect(ctx context.Context, id string, head http.Header, r eventResolver, conn *websocket.Conn) *connect {
+func newConnect(
+	ctx context.Context, id string, head http.Header, r eventResolver,
+	conn *websocket.Conn, codec event.Codec, conf *connConfig,
+) *connect {
 	ctx, cancel := context.WithCancel(ctx)
 	return &connect{
 		id:         id,
 		header:     head,
 		resolver:   r,
 		conn:       conn,
-		dataC:      make(chan []byte, internal.BusBufferSize),
+		codec:      codec,
+		conf:       conf,
+		dataC:      make(chan []byte, conf.bufferSize),
+		calls:      syncing.NewMap[string, chan []byte](2),
 		ctx:        ctx,
 		cancel:     cancel,
 		closeFuncs: syncing.NewSlice[func(cid string)](2),
@@ -66,10 +78,23 @@ func (v *connect) Head(key string) string {
 	return v.header.Get(key)
 }
 
+// Principal getting the value returned by HandshakeGuard, nil if there is no one
+func (v *connect) Principal() any {
+	return v.principal
+}
+
 func (v *connect) Connect() *websocket.Conn {
 	return v.conn
 }
 
+// MessageType getting the websocket frame type of the codec
+func (v *connect) MessageType() int {
+	if v.codec.Binary() {
+		return websocket.BinaryMessage
+	}
+	return websocket.TextMessage
+}
+
 func (v *connect) Done() <-chan struct{} {
 	return v.ctx.Done()
 }
@@ -85,10 +110,13 @@ func (v *connect) Run() {
 		}
 	}
 
-	internal.SetupPingPong(v.conn)
+	if v.conf.maxMessageSize > 0 {
+		v.conn.SetReadLimit(v.conf.maxMessageSize)
+	}
+	internal.SetupPingPong(v.conn, v.conf.pongWait)
 
 	wg := syncing.NewGroup(v.ctx)
-	wg.Background("pump write", func(_ context.Context) { internal.PumpWrite(v) })
+	wg.Background("pump write", func(_ context.Context) { internal.PumpWrite(v, v.conf.pingPeriod, v.conf.pongWait) })
 	wg.Background("pump read", func(_ context.Context) { internal.PumpRead(v) })
 	wg.Wait()
 
@@ -125,48 +153,116 @@ func (v *connect) ReceiveMessage(b []byte) {
 	ev := event.Pool.Get()
 	defer event.Pool.Put(ev)
 
-	if err := json.Unmarshal(b, ev); err != nil {
+	ev.WithCodec(v.codec)
+	if err := ev.Unmarshal(b); err != nil {
 		logx.Error("WS Connect", "do", "decode receive message", "err", err, "cid", v.ConnectID())
 		return
 	}
 
+	if v.resolveCall(ev.CorrelationID(), b) {
+		return
+	}
+
+	ctx, span := trace.StartLinked(v.ctx, "WS event "+strconv.Itoa(int(ev.ID())), trace.KindConsumer,
+		trace.SpanFromContext(v.ctx).Context())
+	span.SetAttr("ws.event_id", int(ev.ID()))
+	span.SetAttr("ws.connect_id", v.id)
+
 	call, ok := v.resolver.GetEventHandler(ev.ID())
 	if !ok {
 		ev.WithError(internal.ErrUnknownEventID)
-	} else if err := call(ev, v); err != nil {
+		span.SetError(internal.ErrUnknownEventID)
+	} else if err := call(ev, &eventMeta{connect: v, ctx: ctx}); err != nil {
 		ev.WithError(err)
+		span.SetError(err)
 	}
+	span.End()
 
-	if bb, err := json.Marshal(ev); err != nil {
+	if bb, err := ev.Marshal(); err != nil {
 		logx.Error("WS Connect", "do", "encode receive message", "err", err, "cid", v.ConnectID())
 	} else {
 		v.SendRawMessage(bb)
 	}
 }
 
+// eventMeta meta of the received event, the context carries the span of the event
+type eventMeta struct {
+	*connect
+	ctx context.Context
+}
+
+func (v *eventMeta) Context() context.Context {
+	return v.ctx
+}
+
 func (v *connect) SendRawMessage(message []byte) {
+	v.sendRawMessage(message)
+}
+
+func (v *connect) sendRawMessage(message []byte) bool {
 	if len(message) == 0 {
-		return
+		return false
 	}
 	select {
 	case v.dataC <- message:
+		return true
 	default:
-		logx.Error("WS Connect", "do", "send message", "err", "write chan is full", "cid", v.ConnectID())
 	}
-	return
-}
 
-func (v *connect) SendEvent(eventId event.Id, message any) {
-	ev := event.Pool.Get()
-	defer event.Pool.Put(ev)
+	switch v.conf.policy {
+	case DropOldest:
+		for v.ctx.Err() == nil {
+			select {
+			case v.dataC <- message:
+				return true
+			default:
+			}
+			select {
+			case <-v.dataC:
+				v.conf.counters.dropped.Add(1)
+			default:
+			}
+		}
+		return false
 
-	ev.WithID(eventId)
-	if err := ev.Encode(message); err != nil {
-		logx.Error("WS Connect", "do", "encode message", "err", err, "cid", v.ConnectID())
-		return
+	case Block:
+		timeout := v.conf.blockTimeout
+		if timeout <= 0 {
+			timeout = internal.BlockTimeout
+		}
+		timer := time.NewTimer(timeout)
+		defer timer.Stop()
+		select {
+		case v.dataC <- message:
+			return true
+		case <-v.Done():
+			return false
+		case <-timer.C:
+			v.disconnect("block")
+			return false
+		}
+
+	case Disconnect:
+		v.disconnect("disconnect")
+		return false
+
+	default:
 	}
 
-	b, err := json.Marshal(ev)
+	v.conf.counters.dropped.Add(1)
+	logx.Error("WS Connect", "do", "send message", "err", errSendQueueFull, "cid", v.ConnectID())
+	return false
+}
+
+func (v *connect) disconnect(policy string) {
+	v.conf.counters.dropped.Add(1)
+	v.conf.counters.disconnected.Add(1)
+	logx.Warn("WS Connect", "do", "send message", "err", errSendQueueFull, "cid", v.ConnectID(), "policy", policy)
+	v.Close()
+}
+
+func (v *connect) SendEvent(eventId event.Id, message any) {
+	b, err := encodeEvent(v.codec, eventId, message)
 	if err != nil {
 		logx.Error("WS Connect", "do", "encode event", "err", err, "cid", v.ConnectID())
 		return
@@ -0,0 +1,118 @@
+/*
+ *  Copyright (c) 2022-2026 Mikhail Knyazhev <markus621@yandex.com>. All rights reserved.
+ *  Use of this source code is governed by a BSD 3-Clause license that can be found in the LICENSE file.
+ */
+
+package ws
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"go.osspkg.com/casecheck"
+
+	"go.osspkg.com/goppy/v3/plugins/trace"
+	"go.osspkg.com/goppy/v3/plugins/ws/event"
+)
+
+func TestUnit_SlowConsumer(t *testing.T) {
+	newConn := func(policy SlowConsumerPolicy) *connect {
+		o := &options{conn: newConnConfig()}
+		BufferSize(2)(o)
+		SlowConsumer(policy, 10*time.Millisecond)(o)
+		return newConnect(context.TODO(), "1", nil, nil, nil, event.JSON, o.conn)
+	}
+	read := func(c *connect) (result []string) {
+		for len(c.dataC) > 0 {
+			result = append(result, string(<-c.dataC))
+		}
+		return
+	}
+
+	c := newConn(DropNewest)
+	casecheck.True(t, c.sendRawMessage([]byte("1")))
+	casecheck.True(t, c.sendRawMessage([]byte("2")))
+	casecheck.False(t, c.sendRawMessage([]byte("3")))
+	casecheck.Equal(t, []string{"1", "2"}, read(c))
+	casecheck.Equal(t, Stats{Dropped: 1}, c.conf.counters.Stats())
+
+	c = newConn(DropOldest)
+	casecheck.True(t, c.sendRawMessage([]byte("1")))
+	casecheck.True(t, c.sendRawMessage([]byte("2")))
+	casecheck.True(t, c.sendRawMessage([]byte("3")))
+	casecheck.Equal(t, []string{"2", "3"}, read(c))
+	casecheck.Equal(t, Stats{Dropped: 1}, c.conf.counters.Stats())
+
+	c = newConn(Block)
+	casecheck.True(t, c.sendRawMessage([]byte("1")))
+	casecheck.True(t, c.sendRawMessage([]byte("2")))
+	go func() {
+		time.Sleep(time.Millisecond)
+		<-c.dataC
+	}()
+	casecheck.True(t, c.sendRawMessage([]byte("3")))
+	casecheck.False(t, c.sendRawMessage([]byte("4")))
+	casecheck.Equal(t, []string{"2", "3"}, read(c))
+	casecheck.Equal(t, Stats{Dropped: 1, Disconnected: 1}, c.conf.counters.Stats())
+
+	c = newConn(Disconnect)
+	casecheck.True(t, c.sendRawMessage([]byte("1")))
+	casecheck.True(t, c.sendRawMessage([]byte("2")))
+	casecheck.False(t, c.sendRawMessage([]byte("3")))
+	casecheck.Equal(t, []string{"1", "2"}, read(c))
+	casecheck.Equal(t, Stats{Dropped: 1, Disconnected: 1}, c.conf.counters.Stats())
+}
+
+func TestUnit_EventSpan(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	exp := trace.NewMemoryExporter()
+	trace.SetExporter(exp)
+	defer trace.SetExporter(nil)
+
+	const traceparent = "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+	connSpan, ok := trace.ParseTraceparent(traceparent)
+	casecheck.True(t, ok)
+
+	srv := NewServer(ctx)
+	srv.SetEventHandler(func(ev event.Event, meta Meta) error {
+		_, span := trace.Start(meta.Context(), "handler", trace.KindInternal)
+		span.End()
+		return ev.Encode("pong")
+	}, 1)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.Header.Set(trace.HeaderTraceparent, traceparent)
+		srv.HandlingHTTP(w, r)
+	}))
+	defer ts.Close()
+
+	cli := NewClient(ctx)
+	serverId, err := cli.Open("ws" + strings.TrimPrefix(ts.URL, "http"))
+	casecheck.NoError(t, err)
+	for cli.CountConn() == 0 {
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	var out string
+	casecheck.NoError(t, cli.Call(ctx, serverId, 1, "ping", &out))
+	casecheck.Equal(t, "pong", out)
+
+	cli.CloseAll()
+	srv.CloseAll()
+
+	spans := exp.Spans()
+	casecheck.Equal(t, 2, len(spans))
+	casecheck.Equal(t, "handler", spans[0].Name)
+	casecheck.Equal(t, "WS event 1", spans[1].Name)
+	casecheck.Equal(t, spans[1].TraceID, spans[0].TraceID)
+	casecheck.Equal(t, spans[1].SpanID, spans[0].ParentSpanID)
+	casecheck.NotEqual(t, connSpan.TraceID, spans[1].TraceID)
+	casecheck.False(t, spans[1].ParentSpanID.IsValid())
+	casecheck.Equal(t, []trace.SpanContext{connSpan}, spans[1].Links)
+}
@@ -0,0 +1,145 @@
+/*
+ *  Copyright (c) 2022-2026 Mikhail Knyazhev <markus621@yandex.com>. All rights reserved.
+ *  Use of this source code is governed by a BSD 3-Clause license that can be found in the LICENSE file.
+ */
+
+package event
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/proto"
+)
+
+type (
+	// Codec encodes the payload and the envelope of the event,
+	// the name is used as the websocket subprotocol
+	Codec interface {
+		Name() string
+		// Binary the messages are sent as binary frames
+		Binary() bool
+		Encode(in any) ([]byte, error)
+		Decode(b []byte, out any) error
+		Marshal(f *Frame) ([]byte, error)
+		Unmarshal(b []byte, f *Frame) error
+	}
+
+	jsonCodec  struct{}
+	protoCodec struct{}
+)
+
+var (
+	// JSON text codec, used when the subprotocol is not negotiated
+	JSON Codec = jsonCodec{}
+	// Protobuf binary codec, payloads implementing proto.Message are encoded as protobuf, other as JSON
+	Protobuf Codec = protoCodec{}
+)
+
+/**********************************************************************************************************************/
+
+func (jsonCodec) Name() string { return "goppy.json" }
+
+func (jsonCodec) Binary() bool { return false }
+
+func (jsonCodec) Encode(in any) ([]byte, error) { return json.Marshal(in) }
+
+func (jsonCodec) Decode(b []byte, out any) error { return json.Unmarshal(b, out) }
+
+func (jsonCodec) Marshal(f *Frame) ([]byte, error) { return f.MarshalJSON() }
+
+func (jsonCodec) Unmarshal(b []byte, f *Frame) error { return f.UnmarshalJSON(b) }
+
+/**********************************************************************************************************************/
+
+const (
+	protoFieldId   protowire.Number = 1
+	protoFieldCid  protowire.Number = 2
+	protoFieldData protowire.Number = 3
+	protoFieldErr  protowire.Number = 4
+)
+
+func (protoCodec) Name() string { return "goppy.proto" }
+
+func (protoCodec) Binary() bool { return true }
+
+func (protoCodec) Encode(in any) ([]byte, error) {
+	if msg, ok := in.(proto.Message); ok {
+		return proto.Marshal(msg)
+	}
+	return json.Marshal(in)
+}
+
+func (protoCodec) Decode(b []byte, out any) error {
+	if msg, ok := out.(proto.Message); ok {
+		return proto.Unmarshal(b, msg)
+	}
+	return json.Unmarshal(b, out)
+}
+
+func (protoCodec) Marshal(f *Frame) ([]byte, error) {
+	b := make([]byte, 0, len(f.Data)+len(f.Cid)+16)
+	b = protowire.AppendTag(b, protoFieldId, protowire.VarintType)
+	b = protowire.AppendVarint(b, uint64(f.Id))
+	if len(f.Cid) > 0 {
+		b = protowire.AppendTag(b, protoFieldCid, protowire.BytesType)
+		b = protowire.AppendString(b, f.Cid)
+	}
+	if len(f.Data) > 0 {
+		b = protowire.AppendTag(b, protoFieldData, protowire.BytesType)
+		b = protowire.AppendBytes(b, f.Data)
+	}
+	if f.Err != nil {
+		b = protowire.AppendTag(b, protoFieldErr, protowire.BytesType)
+		b = protowire.AppendString(b, *f.Err)
+	}
+	return b, nil
+}
+
+func (protoCodec) Unmarshal(b []byte, f *Frame) error {
+	for len(b) > 0 {
+		num, typ, n := protowire.ConsumeTag(b)
+		if n < 0 {
+			return protowire.ParseError(n)
+		}
+		b = b[n:]
+
+		switch {
+		case num == protoFieldId && typ == protowire.VarintType:
+			v, n := protowire.ConsumeVarint(b)
+			if n < 0 {
+				return protowire.ParseError(n)
+			}
+			if v > uint64(^Id(0)) {
+				return fmt.Errorf("event id overflow: %d", v)
+			}
+			f.Id, b = Id(v), b[n:]
+		case num == protoFieldCid && typ == protowire.BytesType:
+			v, n := protowire.ConsumeString(b)
+			if n < 0 {
+				return protowire.ParseError(n)
+			}
+			f.Cid, b = v, b[n:]
+		case num == protoFieldData && typ == protowire.BytesType:
+			v, n := protowire.ConsumeBytes(b)
+			if n < 0 {
+				return protowire.ParseError(n)
+			}
+			f.Data, b = append(f.Data[:0], v...), b[n:]
+		case num == protoFieldErr && typ == protowire.BytesType:
+			v, n := protowire.ConsumeString(b)
+			if n < 0 {
+				return protowire.ParseError(n)
+			}
+			f.Err, b = &v, b[n:]
+		default:
+			n = protowire.ConsumeFieldValue(num, typ, b)
+			if n < 0 {
+				return protowire.ParseError(n)
+			}
+			b = b[n:]
+		}
+	}
+	return nil
+}
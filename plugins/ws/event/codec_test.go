@@ -0,0 +1,52 @@
+/*
+ *  Copyright (c) 2022-2026 Mikhail Knyazhev <markus621@yandex.com>. All rights reserved.
+ *  Use of this source code is governed by a BSD 3-Clause license that can be found in the LICENSE file.
+ */
+
+package event
+
+import (
+	"testing"
+
+	"go.osspkg.com/casecheck"
+	"go.osspkg.com/errors"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+var errTest = errors.New("test error")
+
+func TestUnit_Codec(t *testing.T) {
+	for _, codec := range []Codec{JSON, Protobuf} {
+		t.Run(codec.Name(), func(t *testing.T) {
+			ev := Pool.Get()
+			ev.Reset()
+			ev.WithCodec(codec)
+			ev.WithID(10)
+			ev.WithCorrelationID("abc")
+			casecheck.NoError(t, ev.Encode(wrapperspb.String("hello")))
+
+			b, err := ev.Marshal()
+			casecheck.NoError(t, err)
+
+			ev.Reset()
+			ev.WithCodec(codec)
+			casecheck.NoError(t, ev.Unmarshal(b))
+			casecheck.Equal(t, Id(10), ev.ID())
+			casecheck.Equal(t, "abc", ev.CorrelationID())
+
+			out := &wrapperspb.StringValue{}
+			casecheck.NoError(t, ev.Decode(out))
+			casecheck.Equal(t, "hello", out.GetValue())
+
+			ev.WithError(errTest)
+			b, err = ev.Marshal()
+			casecheck.NoError(t, err)
+
+			ev.Reset()
+			ev.WithCodec(codec)
+			casecheck.NoError(t, ev.Unmarshal(b))
+			casecheck.Error(t, ev.Decode(out))
+			casecheck.Equal(t, errTest.Error(), ev.Decode(out).Error())
+		})
+	}
+}
@@ -20,6 +20,14 @@ var (
 
 type Id uint16
 
+// Reserved event ids handled by the server
+const (
+	// RoomJoin subscribes the client to the rooms
+	RoomJoin Id = 65535
+	// RoomLeave unsubscribes the client from the rooms
+	RoomLeave Id = 65534
+)
+
 type (
 	Event interface {
 		ID() Id
@@ -28,14 +36,24 @@ type (
 		Reset()
 		WithError(e error)
 		WithID(id Id)
+		CorrelationID() string
+		WithCorrelationID(cid string)
 	}
 
+	// Frame the envelope of the event which is written by Codec,
+	// Data contains the payload encoded by the same codec
 	//easyjson:json
-	entity struct {
+	Frame struct {
 		Id   Id              `json:"e"`
+		Cid  string          `json:"c,omitempty"`
 		Data json.RawMessage `json:"d,omitempty"`
 		Err  *string         `json:"err,omitempty"`
 	}
+
+	entity struct {
+		Frame
+		codec Codec
+	}
 )
 
 func (v *entity) ID() Id {
@@ -46,11 +64,14 @@ func (v *entity) Decode(in any) error {
 	if v.Err != nil {
 		return fmt.Errorf("%s", *v.Err)
 	}
-	return json.Unmarshal(v.Data, in)
+	if in == nil {
+		return nil
+	}
+	return v.Codec().Decode(v.Data, in)
 }
 
 func (v *entity) Encode(in any) (err error) {
-	v.Data, err = json.Marshal(in)
+	v.Data, err = v.Codec().Encode(in)
 	if err != nil {
 		return err
 	}
@@ -58,12 +79,44 @@ func (v *entity) Encode(in any) (err error) {
 	return nil
 }
 
+// Codec getting the codec of the payload and the envelope, JSON by default
+func (v *entity) Codec() Codec {
+	if v.codec == nil {
+		return JSON
+	}
+	return v.codec
+}
+
+// WithCodec setting the codec, it must be set before Encode and Unmarshal
+func (v *entity) WithCodec(c Codec) {
+	v.codec = c
+}
+
+// Marshal encoding the envelope by the codec
+func (v *entity) Marshal() ([]byte, error) {
+	return v.Codec().Marshal(&v.Frame)
+}
+
+// Unmarshal decoding the envelope by the codec
+func (v *entity) Unmarshal(b []byte) error {
+	return v.Codec().Unmarshal(b, &v.Frame)
+}
+
 func (v *entity) WithID(id Id) {
 	v.Id = id
 }
 
+// CorrelationID getting the id which matches the response to the request, empty for the notifications
+func (v *entity) CorrelationID() string {
+	return v.Cid
+}
+
+func (v *entity) WithCorrelationID(cid string) {
+	v.Cid = cid
+}
+
 func (v *entity) Reset() {
-	v.Id, v.Err, v.Data = 0, nil, v.Data[:0]
+	v.Id, v.Cid, v.Err, v.Data, v.codec = 0, "", nil, v.Data[:0], nil
 }
 
 func (v *entity) WithError(err error) {
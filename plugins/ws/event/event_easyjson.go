@@ -18,7 +18,7 @@ var (
 	_ easyjson.Marshaler
 )
 
-func easyjsonF642ad3eDecodeGoOsspkgComGoppyV3PluginsWsEvent(in *jlexer.Lexer, out *entity) {
+func easyjsonF642ad3eDecodeGoOsspkgComGoppyV3PluginsWsEvent(in *jlexer.Lexer, out *Frame) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -38,6 +38,12 @@ func easyjsonF642ad3eDecodeGoOsspkgComGoppyV3PluginsWsEvent(in *jlexer.Lexer, ou
 			} else {
 				out.Id = Id(in.Uint16())
 			}
+		case "c":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Cid = string(in.String())
+			}
 		case "d":
 			if in.IsNull() {
 				in.Skip()
@@ -70,7 +76,7 @@ func easyjsonF642ad3eDecodeGoOsspkgComGoppyV3PluginsWsEvent(in *jlexer.Lexer, ou
 		in.Consumed()
 	}
 }
-func easyjsonF642ad3eEncodeGoOsspkgComGoppyV3PluginsWsEvent(out *jwriter.Writer, in entity) {
+func easyjsonF642ad3eEncodeGoOsspkgComGoppyV3PluginsWsEvent(out *jwriter.Writer, in Frame) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -79,6 +85,11 @@ func easyjsonF642ad3eEncodeGoOsspkgComGoppyV3PluginsWsEvent(out *jwriter.Writer,
 		out.RawString(prefix[1:])
 		out.Uint16(uint16(in.Id))
 	}
+	if in.Cid != "" {
+		const prefix string = ",\"c\":"
+		out.RawString(prefix)
+		out.String(string(in.Cid))
+	}
 	if len(in.Data) != 0 {
 		const prefix string = ",\"d\":"
 		out.RawString(prefix)
@@ -93,25 +104,25 @@ func easyjsonF642ad3eEncodeGoOsspkgComGoppyV3PluginsWsEvent(out *jwriter.Writer,
 }
 
 // MarshalJSON supports json.Marshaler interface
-func (v entity) MarshalJSON() ([]byte, error) {
+func (v Frame) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
 	easyjsonF642ad3eEncodeGoOsspkgComGoppyV3PluginsWsEvent(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
-func (v entity) MarshalEasyJSON(w *jwriter.Writer) {
+func (v Frame) MarshalEasyJSON(w *jwriter.Writer) {
 	easyjsonF642ad3eEncodeGoOsspkgComGoppyV3PluginsWsEvent(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
-func (v *entity) UnmarshalJSON(data []byte) error {
+func (v *Frame) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
 	easyjsonF642ad3eDecodeGoOsspkgComGoppyV3PluginsWsEvent(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *entity) UnmarshalEasyJSON(l *jlexer.Lexer) {
+func (v *Frame) UnmarshalEasyJSON(l *jlexer.Lexer) {
 	easyjsonF642ad3eDecodeGoOsspkgComGoppyV3PluginsWsEvent(l, v)
 }
@@ -28,6 +28,12 @@ func TestUnit_Event(t *testing.T) {
 	casecheck.NotNil(t, b)
 	casecheck.Equal(t, `{"e":1,"d":{"id":123}}`, string(b))
 
+	ev.WithCorrelationID("abc")
+	b, err = json.Marshal(ev)
+	casecheck.NoError(t, err)
+	casecheck.Equal(t, `{"e":1,"c":"abc","d":{"id":123}}`, string(b))
+	casecheck.Equal(t, "abc", ev.CorrelationID())
+
 	ev.Reset()
 	err = json.Unmarshal([]byte("{}"), &ev)
 	casecheck.NoError(t, err)
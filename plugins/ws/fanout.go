@@ -0,0 +1,155 @@
+/*
+ *  Copyright (c) 2022-2026 Mikhail Knyazhev <markus621@yandex.com>. All rights reserved.
+ *  Use of this source code is governed by a BSD 3-Clause license that can be found in the LICENSE file.
+ */
+
+package ws
+
+import (
+	"crypto/rand"
+	"fmt"
+	"sync"
+
+	"go.osspkg.com/logx"
+
+	"go.osspkg.com/goppy/v3/plugins/ws/event"
+)
+
+// BusKind type of the relayed send
+type BusKind uint8
+
+const (
+	BusBroadcast BusKind = iota + 1
+	BusRoom
+	BusTarget
+)
+
+type (
+	// Bus relays the sends of the server between the nodes,
+	// the messages published by the node must not be delivered back to it
+	Bus interface {
+		Publish(msg *BusMessage) error
+		Subscribe(call func(msg *BusMessage))
+	}
+
+	// BusMessage the send relayed by Bus, payloads are encoded by every codec of the server
+	BusMessage struct {
+		Node      string            `json:"n"`
+		Kind      BusKind           `json:"k"`
+		Room      string            `json:"r,omitempty"`
+		ClientIDs []string          `json:"c,omitempty"`
+		EventID   event.Id          `json:"e"`
+		Payloads  map[string][]byte `json:"p"`
+	}
+)
+
+// FanOut relays broadcast, room and targeted sends to the other nodes through the bus
+func FanOut(bus Bus) Option {
+	return func(o *options) {
+		o.bus = bus
+	}
+}
+
+func newNodeID() string {
+	return rand.Text()
+}
+
+// publish sending the event to the other nodes if the bus is set
+func (v *_server) publish(kind BusKind, room string, clientIDs []string, eventId event.Id, message any) error {
+	if v.bus == nil {
+		return nil
+	}
+
+	msg := &BusMessage{
+		Node:      v.node,
+		Kind:      kind,
+		Room:      room,
+		ClientIDs: clientIDs,
+		EventID:   eventId,
+		Payloads:  make(map[string][]byte, len(v.codecs)),
+	}
+	for _, c := range v.codecs {
+		b, err := c.Encode(message)
+		if err != nil {
+			return fmt.Errorf("encode message for id '%d' by '%s': %w", eventId, c.Name(), err)
+		}
+		msg.Payloads[c.Name()] = b
+	}
+
+	if err := v.bus.Publish(msg); err != nil {
+		return fmt.Errorf("publish event for id '%d': %w", eventId, err)
+	}
+	return nil
+}
+
+// relay delivering the event from the other node to the local connections
+func (v *_server) relay(msg *BusMessage) {
+	if msg == nil || msg.Node == v.node {
+		return
+	}
+
+	var conns []*connect
+	switch msg.Kind {
+	case BusBroadcast:
+		conns = v.allConns()
+	case BusRoom:
+		conns = v.localConns(v.rooms.Members(msg.Room))
+	case BusTarget:
+		conns = v.localConns(msg.ClientIDs)
+	default:
+		logx.Warn("WS Server", "do", "relay event", "err", "unknown kind", "kind", msg.Kind, "node", msg.Node)
+		return
+	}
+
+	if err := sendEvent(conns, payloadEncoder(msg.EventID, msg.Payloads)); err != nil {
+		logx.Error("WS Server", "do", "relay event", "err", err, "eventId", msg.EventID, "node", msg.Node)
+	}
+}
+
+// allConns getting all the connections of the current node
+func (v *_server) allConns() []*connect {
+	conns := make([]*connect, 0, v.clients.Size())
+	for _, conn := range v.clients.Yield() {
+		conns = append(conns, conn)
+	}
+	return conns
+}
+
+// localConns getting the connections of the current node by ids
+func (v *_server) localConns(clientIDs []string) []*connect {
+	conns := make([]*connect, 0, len(clientIDs))
+	for _, id := range clientIDs {
+		if conn, ok := v.clients.Get(id); ok {
+			conns = append(conns, conn)
+		}
+	}
+	return conns
+}
+
+/**********************************************************************************************************************/
+
+type memoryBus struct {
+	subs []func(msg *BusMessage)
+	mux  sync.RWMutex
+}
+
+// NewMemoryBus in-process bus for the servers of the same process, for example in tests
+func NewMemoryBus() Bus {
+	return &memoryBus{}
+}
+
+func (v *memoryBus) Publish(msg *BusMessage) error {
+	v.mux.RLock()
+	defer v.mux.RUnlock()
+
+	for _, call := range v.subs {
+		call(msg)
+	}
+	return nil
+}
+
+func (v *memoryBus) Subscribe(call func(msg *BusMessage)) {
+	v.mux.Lock()
+	v.subs = append(v.subs, call)
+	v.mux.Unlock()
+}
@@ -0,0 +1,289 @@
+/*
+ *  Copyright (c) 2022-2026 Mikhail Knyazhev <markus621@yandex.com>. All rights reserved.
+ *  Use of this source code is governed by a BSD 3-Clause license that can be found in the LICENSE file.
+ */
+
+package ws
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net"
+	"sync"
+	"time"
+
+	"go.osspkg.com/errors"
+	"go.osspkg.com/logx"
+	"go.osspkg.com/syncing"
+)
+
+const (
+	tcpBusWriteTimeout = 5 * time.Second
+	tcpBusQueueSize    = 1024
+)
+
+var (
+	errTCPBusQueueFull = errors.New("ws bus: peer queue is full")
+	errTCPBusSignature = errors.New("ws bus: invalid message signature")
+)
+
+// TCPBus reference Bus over plain TCP, every node listens the address and sends the messages
+// to all the peers as JSON lines. Every peer has own connection and queue, the connection is dialed
+// on demand and redialed after the failure, the message is dropped if the queue of the peer is full.
+//
+// The traffic is not encrypted: bind the listener to the private network only. If the secret is set,
+// every message is signed by HMAC-SHA256 and the messages with invalid signature are rejected,
+// all the nodes must use the same secret.
+type TCPBus struct {
+	addr   string
+	secret []byte
+	ln     net.Listener
+	subs   []func(msg *BusMessage)
+	peers  map[string]*tcpPeer
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     syncing.Group
+	mux    sync.RWMutex
+}
+
+func NewTCPBus(ctx context.Context, addr string, peers ...string) *TCPBus {
+	ctx, cancel := context.WithCancel(ctx)
+	v := &TCPBus{
+		addr:   addr,
+		peers:  make(map[string]*tcpPeer, len(peers)),
+		ctx:    ctx,
+		cancel: cancel,
+		wg:     syncing.NewGroup(ctx),
+	}
+	v.AddPeers(peers...)
+	return v
+}
+
+// SetSecret sets the shared secret of the nodes, must be called before Up and Publish
+func (v *TCPBus) SetSecret(secret string) {
+	v.mux.Lock()
+	v.secret = []byte(secret)
+	v.mux.Unlock()
+}
+
+func (v *TCPBus) Up() error {
+	ln, err := (&net.ListenConfig{}).Listen(v.ctx, "tcp", v.addr)
+	if err != nil {
+		return errors.Wrapf(err, "ws bus: listen %s", v.addr)
+	}
+	v.ln = ln
+
+	v.wg.Background("ws bus accept", func(ctx context.Context) {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				if ctx.Err() == nil {
+					logx.Error("WS Bus", "do", "accept", "err", err, "addr", v.addr)
+				}
+				return
+			}
+			v.wg.Background("ws bus read", func(ctx context.Context) {
+				v.read(ctx, conn)
+			})
+		}
+	})
+
+	return nil
+}
+
+func (v *TCPBus) Down() error {
+	v.cancel()
+
+	var err error
+	if v.ln != nil {
+		err = v.ln.Close()
+	}
+
+	v.wg.Wait()
+	return err
+}
+
+// AddPeers adding the addresses of the other nodes
+func (v *TCPBus) AddPeers(peers ...string) {
+	v.mux.Lock()
+	defer v.mux.Unlock()
+
+	for _, addr := range peers {
+		if _, ok := v.peers[addr]; ok {
+			continue
+		}
+		p := &tcpPeer{
+			addr:   addr,
+			queueC: make(chan []byte, tcpBusQueueSize),
+		}
+		v.peers[addr] = p
+		v.wg.Background("ws bus peer", p.run)
+	}
+}
+
+// Addr getting the listened address
+func (v *TCPBus) Addr() string {
+	if v.ln == nil {
+		return v.addr
+	}
+	return v.ln.Addr().String()
+}
+
+func (v *TCPBus) Subscribe(call func(msg *BusMessage)) {
+	v.mux.Lock()
+	v.subs = append(v.subs, call)
+	v.mux.Unlock()
+}
+
+// Publish queues the message for every peer without waiting for the delivery
+func (v *TCPBus) Publish(msg *BusMessage) error {
+	b, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+
+	v.mux.RLock()
+	defer v.mux.RUnlock()
+
+	if len(v.secret) > 0 {
+		b = append([]byte(hex.EncodeToString(signBusMessage(v.secret, b))+" "), b...)
+	}
+	b = append(b, '\n')
+
+	var result error
+	for _, p := range v.peers {
+		select {
+		case p.queueC <- b:
+		default:
+			result = errors.Wrap(result, errors.Wrapf(errTCPBusQueueFull, "peer %s", p.addr))
+		}
+	}
+	return result
+}
+
+func (v *TCPBus) read(ctx context.Context, conn net.Conn) {
+	stop := context.AfterFunc(ctx, func() {
+		conn.Close() //nolint:errcheck
+	})
+	defer func() {
+		stop()
+		conn.Close() //nolint:errcheck
+	}()
+
+	v.mux.RLock()
+	secret := v.secret
+	v.mux.RUnlock()
+
+	scanner := bufio.NewScanner(conn)
+	scanner.Buffer(make([]byte, 0, 4096), 16<<20)
+	for scanner.Scan() {
+		b := scanner.Bytes()
+		if len(secret) > 0 {
+			sign, body, ok := bytes.Cut(b, []byte(" "))
+			if !ok || !verifyBusMessage(secret, sign, body) {
+				logx.Error("WS Bus", "do", "verify message", "err", errTCPBusSignature, "remote", conn.RemoteAddr().String())
+				return
+			}
+			b = body
+		}
+
+		msg := &BusMessage{}
+		if err := json.Unmarshal(b, msg); err != nil {
+			logx.Error("WS Bus", "do", "decode message", "err", err, "remote", conn.RemoteAddr().String())
+			continue
+		}
+
+		v.mux.RLock()
+		subs := v.subs
+		v.mux.RUnlock()
+
+		for _, call := range subs {
+			call(msg)
+		}
+	}
+	if err := scanner.Err(); err != nil && ctx.Err() == nil {
+		logx.Error("WS Bus", "do", "read message", "err", err, "remote", conn.RemoteAddr().String())
+	}
+}
+
+func signBusMessage(secret, b []byte) []byte {
+	h := hmac.New(sha256.New, secret)
+	h.Write(b) //nolint:errcheck
+	return h.Sum(nil)
+}
+
+func verifyBusMessage(secret, sign, b []byte) bool {
+	raw := make([]byte, hex.DecodedLen(len(sign)))
+	if _, err := hex.Decode(raw, sign); err != nil {
+		return false
+	}
+	return hmac.Equal(raw, signBusMessage(secret, b))
+}
+
+/**********************************************************************************************************************/
+
+// tcpPeer the connection to the other node, only the goroutine of the peer dials and writes to it
+type tcpPeer struct {
+	addr   string
+	queueC chan []byte
+	conn   net.Conn
+	mux    sync.Mutex
+}
+
+func (v *tcpPeer) run(ctx context.Context) {
+	defer v.close()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case b := <-v.queueC:
+			if err := v.write(ctx, b); err != nil && ctx.Err() == nil {
+				logx.Error("WS Bus", "do", "send message", "err", err, "peer", v.addr)
+			}
+		}
+	}
+}
+
+func (v *tcpPeer) write(ctx context.Context, b []byte) error {
+	v.mux.Lock()
+	defer v.mux.Unlock()
+
+	if v.conn == nil {
+		dialer := &net.Dialer{Timeout: tcpBusWriteTimeout}
+		conn, err := dialer.DialContext(ctx, "tcp", v.addr)
+		if err != nil {
+			return err
+		}
+		v.conn = conn
+	}
+
+	if err := v.conn.SetWriteDeadline(time.Now().Add(tcpBusWriteTimeout)); err != nil {
+		return errors.Wrap(err, v.reset())
+	}
+	if _, err := v.conn.Write(b); err != nil {
+		return errors.Wrap(err, v.reset())
+	}
+	return nil
+}
+
+// reset closes the broken connection, the next message dials the new one
+func (v *tcpPeer) reset() error {
+	err := v.conn.Close()
+	v.conn = nil
+	return err
+}
+
+func (v *tcpPeer) close() {
+	v.mux.Lock()
+	defer v.mux.Unlock()
+
+	if v.conn != nil {
+		v.reset() //nolint:errcheck
+	}
+}
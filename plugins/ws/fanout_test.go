@@ -0,0 +1,181 @@
+/*
+ *  Copyright (c) 2022-2026 Mikhail Knyazhev <markus621@yandex.com>. All rights reserved.
+ *  Use of this source code is governed by a BSD 3-Clause license that can be found in the LICENSE file.
+ */
+
+package ws
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"go.osspkg.com/casecheck"
+
+	"go.osspkg.com/goppy/v3/plugins/ws/event"
+)
+
+type fanOutNode struct {
+	srv       Server
+	cli       Client
+	clientId  string
+	receiveC  chan string
+	closeFunc func()
+}
+
+func newFanOutNode(ctx context.Context, t *testing.T, bus Bus) *fanOutNode {
+	n := &fanOutNode{receiveC: make(chan string, 10)}
+
+	n.srv = NewServerWithOptions(ctx, FanOut(bus), Codecs(event.Protobuf))
+	clientIdC := make(chan string, 1)
+	n.srv.AddOnOpenFunc(func(cid string) { clientIdC <- cid })
+	ts := httptest.NewServer(http.HandlerFunc(n.srv.HandlingHTTP))
+
+	n.cli = NewClient(ctx)
+	n.cli.SetEventHandler(func(ev event.Event, _ Meta) error {
+		var msg string
+		if err := ev.Decode(&msg); err != nil {
+			return err
+		}
+		n.receiveC <- msg
+		return nil
+	}, 1)
+	_, err := n.cli.OpenWithCodecs("ws"+strings.TrimPrefix(ts.URL, "http"), []event.Codec{event.Protobuf})
+	casecheck.NoError(t, err)
+	n.clientId = <-clientIdC
+
+	n.closeFunc = func() {
+		n.cli.CloseAll()
+		n.srv.CloseAll()
+		ts.Close()
+	}
+	return n
+}
+
+func (n *fanOutNode) receive(t *testing.T) string {
+	select {
+	case msg := <-n.receiveC:
+		return msg
+	case <-time.After(5 * time.Second):
+		t.Fatalf("message is not received")
+		return ""
+	}
+}
+
+func testFanOut(ctx context.Context, t *testing.T, bus1, bus2 Bus) {
+	n1 := newFanOutNode(ctx, t, bus1)
+	defer n1.closeFunc()
+	n2 := newFanOutNode(ctx, t, bus2)
+	defer n2.closeFunc()
+
+	casecheck.NoError(t, n1.srv.BroadcastEvent(1, "broadcast"))
+	casecheck.Equal(t, "broadcast", n1.receive(t))
+	casecheck.Equal(t, "broadcast", n2.receive(t))
+
+	casecheck.NoError(t, n1.srv.SendEvent(1, "target", n2.clientId))
+	casecheck.Equal(t, "target", n2.receive(t))
+
+	n2.srv.Join("room", n2.clientId)
+	casecheck.NoError(t, n1.srv.BroadcastRoom("room", 1, "room"))
+	casecheck.Equal(t, "room", n2.receive(t))
+
+	casecheck.Equal(t, 0, len(n1.receiveC))
+}
+
+func TestUnit_FanOutMemory(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	bus := NewMemoryBus()
+	testFanOut(ctx, t, bus, bus)
+}
+
+func TestUnit_FanOutTCP(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	bus1 := NewTCPBus(ctx, "127.0.0.1:0")
+	casecheck.NoError(t, bus1.Up())
+	defer bus1.Down() //nolint:errcheck
+	bus2 := NewTCPBus(ctx, "127.0.0.1:0")
+	casecheck.NoError(t, bus2.Up())
+	defer bus2.Down() //nolint:errcheck
+
+	bus1.AddPeers(bus2.Addr())
+	bus2.AddPeers(bus1.Addr())
+
+	testFanOut(ctx, t, bus1, bus2)
+}
+
+func TestUnit_FanOutTCPSecret(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	newBus := func(secret string) (*TCPBus, chan string) {
+		bus := NewTCPBus(ctx, "127.0.0.1:0")
+		bus.SetSecret(secret)
+		casecheck.NoError(t, bus.Up())
+		receiveC := make(chan string, 10)
+		bus.Subscribe(func(msg *BusMessage) { receiveC <- msg.Node })
+		return bus, receiveC
+	}
+
+	bus1, _ := newBus("secret")
+	defer bus1.Down() //nolint:errcheck
+	bus2, receive2C := newBus("secret")
+	defer bus2.Down() //nolint:errcheck
+	bus3, receive3C := newBus("other")
+	defer bus3.Down() //nolint:errcheck
+
+	bus1.AddPeers(bus2.Addr(), bus3.Addr())
+	casecheck.NoError(t, bus1.Publish(&BusMessage{Node: "n1", Kind: BusBroadcast}))
+
+	select {
+	case node := <-receive2C:
+		casecheck.Equal(t, "n1", node)
+	case <-time.After(5 * time.Second):
+		t.Fatalf("message is not received")
+	}
+	select {
+	case <-receive3C:
+		t.Fatalf("message with invalid signature is received")
+	case <-time.After(200 * time.Millisecond):
+	}
+}
+
+func TestUnit_FanOutTCPDeadPeer(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	ln, err := (&net.ListenConfig{}).Listen(ctx, "tcp", "127.0.0.1:0")
+	casecheck.NoError(t, err)
+	dead := ln.Addr().String()
+	casecheck.NoError(t, ln.Close())
+
+	bus1 := NewTCPBus(ctx, "127.0.0.1:0", dead)
+	casecheck.NoError(t, bus1.Up())
+	defer bus1.Down() //nolint:errcheck
+	bus2 := NewTCPBus(ctx, "127.0.0.1:0")
+	casecheck.NoError(t, bus2.Up())
+	defer bus2.Down() //nolint:errcheck
+	bus1.AddPeers(bus2.Addr())
+
+	receiveC := make(chan struct{}, tcpBusQueueSize+1)
+	bus2.Subscribe(func(_ *BusMessage) { receiveC <- struct{}{} })
+
+	start := time.Now()
+	for i := 0; i < tcpBusQueueSize+1; i++ {
+		bus1.Publish(&BusMessage{Node: "n1", Kind: BusBroadcast}) //nolint:errcheck
+	}
+	casecheck.True(t, time.Since(start) < time.Second)
+
+	select {
+	case <-receiveC:
+	case <-time.After(5 * time.Second):
+		t.Fatalf("message is not received by the live peer")
+	}
+}
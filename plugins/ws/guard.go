@@ -0,0 +1,158 @@
+/*
+ *  Copyright (c) 2022-2026 Mikhail Knyazhev <markus621@yandex.com>. All rights reserved.
+ *  Use of this source code is governed by a BSD 3-Clause license that can be found in the LICENSE file.
+ */
+
+package ws
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"go.osspkg.com/errors"
+	"go.osspkg.com/logx"
+)
+
+type (
+	// HandshakeGuard checks the request before the upgrade, the returned principal is available
+	// by Meta.Principal, the error created by Reject sets the status code of the response
+	HandshakeGuard func(r *http.Request) (principal any, err error)
+
+	// GuardError the rejection of the handshake with the status code
+	GuardError struct {
+		Code int
+		Err  error
+	}
+)
+
+var errNoPrincipal = errors.New("principal is not found")
+
+// Reject creating the error of the guard with the status code
+func Reject(code int, err error) error {
+	return &GuardError{Code: code, Err: err}
+}
+
+func (e *GuardError) Error() string {
+	if e.Err == nil {
+		return http.StatusText(e.Code)
+	}
+	return e.Err.Error()
+}
+
+func (e *GuardError) Unwrap() error {
+	return e.Err
+}
+
+// PrincipalGuard getting the principal from the request context which is set by the middleware,
+// for example token.GetPayloadContext, and rejects the handshake with 401 if it is missing
+func PrincipalGuard[T any](get func(ctx context.Context) (T, bool)) HandshakeGuard {
+	return func(r *http.Request) (any, error) {
+		principal, ok := get(r.Context())
+		if !ok {
+			return nil, Reject(http.StatusUnauthorized, errNoPrincipal)
+		}
+		return principal, nil
+	}
+}
+
+func (v *_server) AddHandshakeGuard(g HandshakeGuard) {
+	v.authGuard.Append(g)
+}
+
+// handshake calling all the guards, returns the principal or the status code of the rejection
+func (v *_server) handshake(clientId string, r *http.Request) (any, int, error) {
+	if err := v.callGuards(clientId, r.Header); err != nil {
+		return nil, guardStatus(err), err
+	}
+
+	var principal any
+	for guardFunc := range v.authGuard.Yield() {
+		if guardFunc == nil {
+			continue
+		}
+		p, err := guardFunc(r)
+		if err != nil {
+			return nil, guardStatus(err), err
+		}
+		if p != nil {
+			principal = p
+		}
+	}
+
+	return principal, http.StatusOK, nil
+}
+
+func guardStatus(err error) int {
+	var ge *GuardError
+	if errors.As(err, &ge) && ge.Code >= 400 {
+		return ge.Code
+	}
+	return http.StatusForbidden
+}
+
+/**********************************************************************************************************************/
+
+// AllowedOrigins sets the origins which can open the connection, the pattern can be '*',
+// the exact origin 'https://example.com' or the subdomains 'https://*.example.com',
+// the empty list allows any origin
+func AllowedOrigins(origins ...string) Option {
+	return func(o *options) {
+		if len(origins) == 0 {
+			return
+		}
+		o.upgrade.CheckOrigin = checkOrigin(origins)
+	}
+}
+
+func checkOrigin(patterns []string) func(r *http.Request) bool {
+	list := make([]string, 0, len(patterns))
+	for _, p := range patterns {
+		list = append(list, strings.ToLower(strings.TrimSuffix(p, "/")))
+	}
+
+	return func(r *http.Request) bool {
+		origin := r.Header.Get("Origin")
+		if len(origin) == 0 {
+			return true
+		}
+		origin = strings.ToLower(origin)
+
+		for _, p := range list {
+			if matchOrigin(p, origin) {
+				return true
+			}
+		}
+		logx.Warn("WS Server", "do", "check origin", "err", "origin is not allowed", "origin", origin)
+		return false
+	}
+}
+
+func matchOrigin(pattern, origin string) bool {
+	if pattern == "*" || pattern == origin {
+		return true
+	}
+	scheme, host, ok := strings.Cut(pattern, "://*.")
+	if !ok {
+		return false
+	}
+	prefix := scheme + "://"
+	return strings.HasPrefix(origin, prefix) && strings.HasSuffix(origin, "."+host) &&
+		len(origin) > len(prefix)+len(host)+1
+}
+
+func validateOrigin(pattern string) error {
+	if pattern == "*" {
+		return nil
+	}
+	u, err := url.Parse(strings.Replace(pattern, "://*.", "://", 1))
+	if err != nil {
+		return err
+	}
+	if len(u.Scheme) == 0 || len(u.Host) == 0 {
+		return fmt.Errorf("origin '%s' must have scheme and host", pattern)
+	}
+	return nil
+}
@@ -0,0 +1,95 @@
+/*
+ *  Copyright (c) 2022-2026 Mikhail Knyazhev <markus621@yandex.com>. All rights reserved.
+ *  Use of this source code is governed by a BSD 3-Clause license that can be found in the LICENSE file.
+ */
+
+package ws
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"go.osspkg.com/casecheck"
+
+	"go.osspkg.com/goppy/v3/plugins/ws/event"
+)
+
+func TestUnit_MatchOrigin(t *testing.T) {
+	check := checkOrigin([]string{"https://example.com/", "https://*.example.org"})
+	for origin, want := range map[string]bool{
+		"":                        true,
+		"https://example.com":     true,
+		"https://EXAMPLE.com":     true,
+		"http://example.com":      false,
+		"https://a.example.org":   true,
+		"https://a.b.example.org": true,
+		"https://example.org":     false,
+		"https://aexample.org":    false,
+		"https://evil.com":        false,
+	} {
+		r := httptest.NewRequest(http.MethodGet, "/", nil)
+		if len(origin) > 0 {
+			r.Header.Set("Origin", origin)
+		}
+		casecheck.Equal(t, want, check(r), origin)
+	}
+
+	casecheck.NoError(t, validateOrigin("*"))
+	casecheck.NoError(t, validateOrigin("https://*.example.org"))
+	casecheck.Error(t, validateOrigin("example.org"))
+}
+
+func TestUnit_HandshakeGuard(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	type principalKey struct{}
+
+	srv := NewServerWithOptions(ctx, AllowedOrigins("https://example.com"))
+	srv.AddHandshakeGuard(PrincipalGuard(func(ctx context.Context) (string, bool) {
+		v, ok := ctx.Value(principalKey{}).(string)
+		return v, ok
+	}))
+	srv.SetEventHandler(func(ev event.Event, meta Meta) error {
+		return ev.Encode(meta.Principal())
+	}, 1)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if user := r.URL.Query().Get("user"); len(user) > 0 {
+			r = r.WithContext(context.WithValue(r.Context(), principalKey{}, user))
+		}
+		srv.HandlingHTTP(w, r)
+	}))
+	defer ts.Close()
+
+	url := "ws" + strings.TrimPrefix(ts.URL, "http")
+
+	_, resp, err := websocket.DefaultDialer.Dial(url, nil)
+	casecheck.Error(t, err)
+	casecheck.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+
+	_, resp, err = websocket.DefaultDialer.Dial(url+"?user=alice", http.Header{"Origin": {"https://evil.com"}})
+	casecheck.Error(t, err)
+	casecheck.Equal(t, http.StatusForbidden, resp.StatusCode)
+
+	cli := NewClient(ctx)
+	serverId, err := cli.Open(url+"?user=alice", func(h http.Header, _ *websocket.Dialer) {
+		h.Set("Origin", "https://example.com")
+	})
+	casecheck.NoError(t, err)
+	for cli.CountConn() == 0 {
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	var principal string
+	casecheck.NoError(t, cli.Call(ctx, serverId, 1, nil, &principal))
+	casecheck.Equal(t, "alice", principal)
+
+	cli.CloseAll()
+	srv.CloseAll()
+}
@@ -18,6 +18,8 @@ const (
 	PongWait      = 60 * time.Second
 	PingPeriod    = PongWait / 3
 	BusBufferSize = 128
+	CallTimeout   = 30 * time.Second
+	BlockTimeout  = time.Second
 )
 
 var (
@@ -36,11 +38,11 @@ func NewUpgrade() *websocket.Upgrader {
 	}
 }
 
-func SetupPingPong(c *websocket.Conn) {
+func SetupPingPong(c *websocket.Conn, pongWait time.Duration) {
 	c.SetPingHandler(func(_ string) error {
-		return c.SetReadDeadline(time.Now().Add(PongWait))
+		return c.SetReadDeadline(time.Now().Add(pongWait))
 	})
 	c.SetPongHandler(func(_ string) error {
-		return c.SetReadDeadline(time.Now().Add(PongWait))
+		return c.SetReadDeadline(time.Now().Add(pongWait))
 	})
 }
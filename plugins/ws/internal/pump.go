@@ -19,6 +19,7 @@ type (
 		ConnectID() string
 		ReceiveMessage(b []byte)
 		SendMessageChan() <-chan []byte
+		MessageType() int
 		Connect() *websocket.Conn
 		Done() <-chan struct{}
 		Close()
@@ -62,8 +63,8 @@ func PumpRead(cc connect) {
 	}
 }
 
-func PumpWrite(cc connect) {
-	ticker := time.NewTicker(PingPeriod)
+func PumpWrite(cc connect, pingPeriod, pongWait time.Duration) {
+	ticker := time.NewTicker(pingPeriod)
 	defer func() {
 		ticker.Stop()
 		cc.Close()
@@ -74,14 +75,14 @@ func PumpWrite(cc connect) {
 	for {
 		select {
 		case <-cc.Done():
-			err := cc.Connect().WriteControl(websocket.CloseMessage, closeMessage, time.Now().Add(PongWait))
+			err := cc.Connect().WriteControl(websocket.CloseMessage, closeMessage, time.Now().Add(pongWait))
 			if err != nil && !IsClosingError(err) {
 				logx.Error("WS Server", "do", "close message", "err", err, "cid", cc.ConnectID())
 			}
 			return
 
 		case <-ticker.C:
-			err := cc.Connect().WriteControl(websocket.PingMessage, nil, time.Now().Add(PongWait))
+			err := cc.Connect().WriteControl(websocket.PingMessage, nil, time.Now().Add(pongWait))
 			if err == nil {
 				continue
 			}
@@ -91,7 +92,7 @@ func PumpWrite(cc connect) {
 			return
 
 		case message := <-cc.SendMessageChan():
-			err := cc.Connect().WriteMessage(websocket.TextMessage, message)
+			err := cc.Connect().WriteMessage(cc.MessageType(), message)
 			if err == nil {
 				continue
 			}
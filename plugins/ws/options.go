@@ -0,0 +1,121 @@
+/*
+ *  Copyright (c) 2022-2026 Mikhail Knyazhev <markus621@yandex.com>. All rights reserved.
+ *  Use of this source code is governed by a BSD 3-Clause license that can be found in the LICENSE file.
+ */
+
+package ws
+
+import (
+	"sync/atomic"
+	"time"
+
+	"github.com/gorilla/websocket"
+
+	"go.osspkg.com/goppy/v3/plugins/ws/event"
+	"go.osspkg.com/goppy/v3/plugins/ws/internal"
+)
+
+// SlowConsumerPolicy the behavior when the write buffer of the connection is full
+type SlowConsumerPolicy int
+
+const (
+	// DropNewest drops the message which is being sent
+	DropNewest SlowConsumerPolicy = iota
+	// DropOldest drops the oldest message in the buffer to make room for the new one
+	DropOldest
+	// Block waits for room in the buffer until the timeout and closes the connection after it
+	Block
+	// Disconnect closes the connection of the slow consumer
+	Disconnect
+)
+
+type (
+	// Option of the websocket server
+	Option func(o *options)
+
+	options struct {
+		upgrade *websocket.Upgrader
+		conn    *connConfig
+		bus     Bus
+		codecs  []event.Codec
+	}
+
+	connConfig struct {
+		bufferSize     int
+		pongWait       time.Duration
+		pingPeriod     time.Duration
+		maxMessageSize int64
+		policy         SlowConsumerPolicy
+		blockTimeout   time.Duration
+		counters       *counters
+	}
+
+	// Stats counters of the messages which are not delivered to the slow consumers
+	Stats struct {
+		Dropped      uint64
+		Disconnected uint64
+	}
+
+	counters struct {
+		dropped      atomic.Uint64
+		disconnected atomic.Uint64
+	}
+)
+
+func newConnConfig() *connConfig {
+	return &connConfig{
+		bufferSize: internal.BusBufferSize,
+		pongWait:   internal.PongWait,
+		pingPeriod: internal.PingPeriod,
+		policy:     DropNewest,
+		counters:   &counters{},
+	}
+}
+
+func (v *counters) Stats() Stats {
+	return Stats{
+		Dropped:      v.dropped.Load(),
+		Disconnected: v.disconnected.Load(),
+	}
+}
+
+// Upgrader changes any setting of the upgrader
+func Upgrader(call func(u *websocket.Upgrader)) Option {
+	return func(o *options) {
+		call(o.upgrade)
+	}
+}
+
+// BufferSize sets the count of the messages queued for writing to the connection
+func BufferSize(size int) Option {
+	return func(o *options) {
+		if size > 0 {
+			o.conn.bufferSize = size
+		}
+	}
+}
+
+// PingPong sets how long to wait for the pong and how often to send the ping,
+// the period must be less than the wait
+func PingPong(pongWait, pingPeriod time.Duration) Option {
+	return func(o *options) {
+		if pongWait > 0 && pingPeriod > 0 && pingPeriod < pongWait {
+			o.conn.pongWait, o.conn.pingPeriod = pongWait, pingPeriod
+		}
+	}
+}
+
+// MaxMessageSize limits the size of the incoming message, the connection is closed if it is exceeded
+func MaxMessageSize(size int64) Option {
+	return func(o *options) {
+		o.conn.maxMessageSize = size
+	}
+}
+
+// SlowConsumer sets the policy for the full write buffer, the timeout is used by the Block policy,
+// one second is used if it is not set
+func SlowConsumer(policy SlowConsumerPolicy, timeout time.Duration) Option {
+	return func(o *options) {
+		o.conn.policy, o.conn.blockTimeout = policy, timeout
+	}
+}
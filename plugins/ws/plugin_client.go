@@ -6,6 +6,7 @@
 package ws
 
 import (
+	"context"
 	"net/http"
 
 	"github.com/gorilla/websocket"
@@ -17,6 +18,7 @@ import (
 
 type Client interface {
 	Open(url string, opts ...func(h http.Header, d *websocket.Dialer)) (string, error)
+	OpenWithCodecs(url string, codecs []event.Codec, opts ...func(h http.Header, d *websocket.Dialer)) (string, error)
 	SetEventHandler(handler EventHandler, eventIDs ...event.Id)
 	DelEventHandler(eventIDs ...event.Id)
 	CountConn() int
@@ -24,14 +26,16 @@ type Client interface {
 	AddOnOpenFunc(callback func(serverId string))
 	CloseConnect(serverId string)
 	CloseAll()
+	Stats() Stats
 	BroadcastEvent(eventId event.Id, message any) error
 	SendEvent(eventId event.Id, message any, serverIDs ...string) error
+	Call(ctx context.Context, serverId string, eventId event.Id, in, out any) error
 }
 
-func WithClient() plugin.Kind {
+func WithClient(options ...ClientOption) plugin.Kind {
 	return plugin.Kind{
 		Inject: func(ctx xc.Context) Client {
-			return NewClient(ctx.Context())
+			return NewClient(ctx.Context(), options...)
 		},
 	}
 }
@@ -6,6 +6,7 @@
 package ws
 
 import (
+	"context"
 	"net/http"
 
 	"github.com/gorilla/websocket"
@@ -22,8 +23,17 @@ type Server interface {
 	SetEventHandler(h EventHandler, eventIDs ...event.Id)
 	DelEventHandler(eventIDs ...event.Id)
 	AddGuard(g Guard)
+	AddHandshakeGuard(g HandshakeGuard)
 	BroadcastEvent(eventId event.Id, m any) (err error)
 	SendEvent(eventId event.Id, m any, clientIDs ...string) (err error)
+	Call(ctx context.Context, clientId string, eventId event.Id, in, out any) error
+	Stats() Stats
+	Join(room string, clientIDs ...string)
+	Leave(room string, clientIDs ...string)
+	Members(room string) []string
+	Rooms(clientId string) []string
+	BroadcastRoom(room string, eventId event.Id, m any) (err error)
+	AddRoomGuard(g RoomGuard)
 	AddOnCloseFunc(call func(clientId string))
 	AddOnOpenFunc(cb func(clientId string))
 	CloseOne(clientId string)
@@ -45,9 +55,20 @@ func ReadWriteBuffer(read, write int) func(*websocket.Upgrader) {
 }
 
 func WithServer(options ...func(*websocket.Upgrader)) plugin.Kind {
+	opts := make([]Option, 0, len(options))
+	for _, opt := range options {
+		opts = append(opts, Upgrader(opt))
+	}
+	return WithServerOptions(opts...)
+}
+
+// WithServerOptions same as WithServer, but takes the options of the connections, the codecs and the fan-out bus
+func WithServerOptions(options ...Option) plugin.Kind {
 	return plugin.Kind{
-		Inject: func(ctx xc.Context) Server {
-			return NewServer(ctx.Context(), options...)
+		Config: &ConfigGroup{},
+		Inject: func(ctx xc.Context, c *ConfigGroup) Server {
+			opts := append([]Option{AllowedOrigins(c.WS.AllowedOrigins...)}, options...)
+			return NewServerWithOptions(ctx.Context(), opts...)
 		},
 	}
 }
@@ -0,0 +1,223 @@
+/*
+ *  Copyright (c) 2022-2026 Mikhail Knyazhev <markus621@yandex.com>. All rights reserved.
+ *  Use of this source code is governed by a BSD 3-Clause license that can be found in the LICENSE file.
+ */
+
+package ws
+
+import (
+	"sort"
+	"sync"
+
+	"go.osspkg.com/errors"
+	"go.osspkg.com/logx"
+
+	"go.osspkg.com/goppy/v3/plugins/ws/event"
+)
+
+type (
+	// RoomGuard checks that the client is allowed to join the room by the reserved event
+	RoomGuard func(cid, room string) error
+
+	// RoomsMessage model of the reserved events event.RoomJoin and event.RoomLeave,
+	// the answer contains all the rooms of the client
+	RoomsMessage struct {
+		Rooms []string `json:"rooms"`
+	}
+
+	rooms struct {
+		rooms   map[string]map[string]struct{}
+		clients map[string]map[string]struct{}
+		mux     sync.RWMutex
+	}
+)
+
+var errEmptyRoomName = errors.New("empty room name")
+
+func newRooms() *rooms {
+	return &rooms{
+		rooms:   make(map[string]map[string]struct{}, 10),
+		clients: make(map[string]map[string]struct{}, 10),
+	}
+}
+
+func (v *rooms) Join(room string, cids ...string) {
+	if len(cids) == 0 {
+		return
+	}
+
+	v.mux.Lock()
+	defer v.mux.Unlock()
+
+	members, ok := v.rooms[room]
+	if !ok {
+		members = make(map[string]struct{}, len(cids))
+		v.rooms[room] = members
+	}
+	for _, cid := range cids {
+		members[cid] = struct{}{}
+		list, ok := v.clients[cid]
+		if !ok {
+			list = make(map[string]struct{}, 2)
+			v.clients[cid] = list
+		}
+		list[room] = struct{}{}
+	}
+}
+
+func (v *rooms) Leave(room string, cids ...string) {
+	v.mux.Lock()
+	defer v.mux.Unlock()
+
+	for _, cid := range cids {
+		v.leave(room, cid)
+	}
+}
+
+func (v *rooms) LeaveAll(cid string) {
+	v.mux.Lock()
+	defer v.mux.Unlock()
+
+	for room := range v.clients[cid] {
+		v.leave(room, cid)
+	}
+}
+
+func (v *rooms) leave(room, cid string) {
+	if members, ok := v.rooms[room]; ok {
+		delete(members, cid)
+		if len(members) == 0 {
+			delete(v.rooms, room)
+		}
+	}
+	if list, ok := v.clients[cid]; ok {
+		delete(list, room)
+		if len(list) == 0 {
+			delete(v.clients, cid)
+		}
+	}
+}
+
+func (v *rooms) Members(room string) []string {
+	v.mux.RLock()
+	defer v.mux.RUnlock()
+
+	return sortedKeys(v.rooms[room])
+}
+
+func (v *rooms) Rooms(cid string) []string {
+	v.mux.RLock()
+	defer v.mux.RUnlock()
+
+	return sortedKeys(v.clients[cid])
+}
+
+func sortedKeys(m map[string]struct{}) []string {
+	result := make([]string, 0, len(m))
+	for key := range m {
+		result = append(result, key)
+	}
+	sort.Strings(result)
+	return result
+}
+
+/**********************************************************************************************************************/
+
+// Join adds the connected clients to the room, unknown and closed clients are skipped
+func (v *_server) Join(room string, clientIDs ...string) {
+	ids := make([]string, 0, len(clientIDs))
+	for _, id := range clientIDs {
+		if v.clients.Has(id) {
+			ids = append(ids, id)
+		}
+	}
+	if len(ids) == 0 {
+		return
+	}
+
+	v.rooms.Join(room, ids...)
+
+	// the client can be closed while joining after its close func has left all rooms
+	for _, id := range ids {
+		if !v.clients.Has(id) {
+			v.rooms.LeaveAll(id)
+		}
+	}
+}
+
+func (v *_server) Leave(room string, clientIDs ...string) {
+	v.rooms.Leave(room, clientIDs...)
+}
+
+func (v *_server) Members(room string) []string {
+	return v.rooms.Members(room)
+}
+
+func (v *_server) Rooms(clientId string) []string {
+	return v.rooms.Rooms(clientId)
+}
+
+func (v *_server) BroadcastRoom(room string, eventId event.Id, message any) error {
+	if members := v.rooms.Members(room); len(members) > 0 {
+		if err := sendEvent(v.localConns(members), eventEncoder(eventId, message)); err != nil {
+			logx.Error("WS Server", "do", "broadcast room", "err", err, "eventId", eventId, "room", room)
+			return err
+		}
+	}
+
+	if err := v.publish(BusRoom, room, nil, eventId, message); err != nil {
+		logx.Error("WS Server", "do", "broadcast room", "err", err, "eventId", eventId, "room", room)
+		return err
+	}
+
+	return nil
+}
+
+func (v *_server) AddRoomGuard(g RoomGuard) {
+	v.roomGuard.Append(g)
+}
+
+func (v *_server) callRoomGuards(clientId, room string) error {
+	for guardFunc := range v.roomGuard.Yield() {
+		if guardFunc == nil {
+			continue
+		}
+		if err := guardFunc(clientId, room); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (v *_server) roomEventHandler(ev event.Event, meta Meta) error {
+	msg := RoomsMessage{}
+	if err := ev.Decode(&msg); err != nil {
+		return err
+	}
+
+	for _, room := range msg.Rooms {
+		if len(room) == 0 {
+			return errEmptyRoomName
+		}
+	}
+
+	for _, room := range msg.Rooms {
+		switch ev.ID() {
+		case event.RoomJoin:
+			if err := v.callRoomGuards(meta.ConnectID(), room); err != nil {
+				logx.Warn("WS Server", "do", "join room: guard", "err", err, "clientId", meta.ConnectID(), "room", room)
+				return err
+			}
+			v.rooms.Join(room, meta.ConnectID())
+			if meta.Context().Err() != nil {
+				v.rooms.LeaveAll(meta.ConnectID())
+				return meta.Context().Err()
+			}
+		case event.RoomLeave:
+			v.rooms.Leave(room, meta.ConnectID())
+		}
+	}
+
+	return ev.Encode(&RoomsMessage{Rooms: v.rooms.Rooms(meta.ConnectID())})
+}
@@ -0,0 +1,98 @@
+/*
+ *  Copyright (c) 2022-2026 Mikhail Knyazhev <markus621@yandex.com>. All rights reserved.
+ *  Use of this source code is governed by a BSD 3-Clause license that can be found in the LICENSE file.
+ */
+
+package ws
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"go.osspkg.com/casecheck"
+	"go.osspkg.com/errors"
+
+	"go.osspkg.com/goppy/v3/plugins/ws/event"
+)
+
+func TestUnit_Rooms(t *testing.T) {
+	r := newRooms()
+
+	r.Join("a", "1", "2")
+	r.Join("b", "2")
+	r.Join("c")
+	casecheck.Equal(t, []string{"1", "2"}, r.Members("a"))
+	casecheck.Equal(t, []string{"a", "b"}, r.Rooms("2"))
+	casecheck.Equal(t, 2, len(r.rooms))
+
+	r.Leave("a", "1")
+	casecheck.Equal(t, []string{"2"}, r.Members("a"))
+	casecheck.Equal(t, []string{}, r.Rooms("1"))
+
+	r.LeaveAll("2")
+	casecheck.Equal(t, []string{}, r.Members("a"))
+	casecheck.Equal(t, []string{}, r.Members("b"))
+	casecheck.Equal(t, 0, len(r.rooms))
+	casecheck.Equal(t, 0, len(r.clients))
+}
+
+func TestUnit_ServerRooms(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	srv := NewServer(ctx)
+	srv.AddRoomGuard(func(_, room string) error {
+		if room == "secret" {
+			return errors.New("forbidden room")
+		}
+		return nil
+	})
+
+	ts := httptest.NewServer(http.HandlerFunc(srv.HandlingHTTP))
+	defer ts.Close()
+
+	cli := NewClient(ctx)
+	serverId, err := cli.Open("ws" + strings.TrimPrefix(ts.URL, "http"))
+	casecheck.NoError(t, err)
+	for cli.CountConn() == 0 || srv.CountConn() == 0 {
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	var out RoomsMessage
+	casecheck.NoError(t, cli.Call(ctx, serverId, event.RoomJoin, RoomsMessage{Rooms: []string{"b", "a"}}, &out))
+	casecheck.Equal(t, []string{"a", "b"}, out.Rooms)
+
+	casecheck.Error(t, cli.Call(ctx, serverId, event.RoomJoin, RoomsMessage{Rooms: []string{"secret"}}, &out))
+	casecheck.Error(t, cli.Call(ctx, serverId, event.RoomJoin, RoomsMessage{Rooms: []string{""}}, &out))
+
+	casecheck.NoError(t, cli.Call(ctx, serverId, event.RoomLeave, RoomsMessage{Rooms: []string{"b"}}, &out))
+	casecheck.Equal(t, []string{"a"}, out.Rooms)
+
+	members := srv.Members("a")
+	casecheck.Equal(t, 1, len(members))
+	clientId := members[0]
+	casecheck.Equal(t, []string{}, srv.Members("secret"))
+
+	srv.Join("c", "unknown")
+	srv.Join("c")
+	casecheck.Equal(t, []string{}, srv.Members("c"))
+	srv.Join("c", clientId, "unknown")
+	casecheck.Equal(t, []string{clientId}, srv.Members("c"))
+	casecheck.Equal(t, []string{"a", "c"}, srv.Rooms(clientId))
+
+	cli.CloseAll()
+	for i := 0; i < 100 && len(srv.Rooms(clientId)) > 0; i++ {
+		time.Sleep(10 * time.Millisecond)
+	}
+	casecheck.Equal(t, []string{}, srv.Members("a"))
+	casecheck.Equal(t, []string{}, srv.Members("c"))
+
+	srv.Join("a", clientId)
+	casecheck.Equal(t, []string{}, srv.Members("a"))
+
+	srv.CloseAll()
+}
@@ -7,8 +7,6 @@ package ws
 
 import (
 	"context"
-	"encoding/json"
-	"fmt"
 	"net/http"
 
 	"github.com/gorilla/websocket"
@@ -18,6 +16,7 @@ import (
 
 	"go.osspkg.com/goppy/v3/pkg/xc"
 
+	"go.osspkg.com/goppy/v3/plugins/trace"
 	"go.osspkg.com/goppy/v3/plugins/web"
 	"go.osspkg.com/goppy/v3/plugins/ws/event"
 	"go.osspkg.com/goppy/v3/plugins/ws/internal"
@@ -26,31 +25,72 @@ import (
 type _server struct {
 	cancel     context.CancelFunc
 	upgrade    *websocket.Upgrader
+	conf       *connConfig
+	bus        Bus
+	node       string
+	codecs     []event.Codec
 	clients    *syncing.Map[string, *connect]
 	events     *syncing.Map[event.Id, EventHandler]
 	guard      *syncing.Slice[Guard]
+	authGuard  *syncing.Slice[HandshakeGuard]
+	roomGuard  *syncing.Slice[RoomGuard]
+	rooms      *rooms
 	openFuncs  *syncing.Slice[func(cid string)]
 	closeFuncs *syncing.Slice[func(cid string)]
 	wg         syncing.Group
 }
 
 func NewServer(ctx context.Context, opts ...func(u *websocket.Upgrader)) Server {
-	upgrade := internal.NewUpgrade()
+	options := make([]Option, 0, len(opts))
+	for _, opt := range opts {
+		options = append(options, Upgrader(opt))
+	}
+	return NewServerWithOptions(ctx, options...)
+}
+
+// NewServerWithOptions creates the server with the options of the connections, the codecs and the fan-out bus
+func NewServerWithOptions(ctx context.Context, opts ...Option) Server {
+	o := &options{
+		upgrade: internal.NewUpgrade(),
+		conn:    newConnConfig(),
+	}
 	ctx, cancel := context.WithCancel(ctx)
 	for _, opt := range opts {
-		opt(upgrade)
+		opt(o)
 	}
 
-	return &_server{
-		upgrade:    upgrade,
+	srv := &_server{
+		upgrade:    o.upgrade,
+		conf:       o.conn,
+		bus:        o.bus,
+		node:       newNodeID(),
+		codecs:     []event.Codec{event.JSON},
 		cancel:     cancel,
 		clients:    syncing.NewMap[string, *connect](10),
 		events:     syncing.NewMap[event.Id, EventHandler](10),
 		guard:      syncing.NewSlice[Guard](2),
+		authGuard:  syncing.NewSlice[HandshakeGuard](2),
+		roomGuard:  syncing.NewSlice[RoomGuard](2),
+		rooms:      newRooms(),
 		openFuncs:  syncing.NewSlice[func(cid string)](2),
 		closeFuncs: syncing.NewSlice[func(cid string)](2),
 		wg:         syncing.NewGroup(ctx),
 	}
+
+	for _, c := range o.codecs {
+		if c.Name() != event.JSON.Name() {
+			srv.codecs = append(srv.codecs, c)
+		}
+	}
+
+	srv.SetEventHandler(srv.roomEventHandler, event.RoomJoin, event.RoomLeave)
+	srv.AddOnCloseFunc(srv.rooms.LeaveAll)
+
+	if srv.bus != nil {
+		srv.bus.Subscribe(srv.relay)
+	}
+
+	return srv
 }
 
 func (v *_server) Up() error {
@@ -66,6 +106,11 @@ func (v *_server) CountConn() int {
 	return v.clients.Size()
 }
 
+// Stats getting the counters of the slow consumers
+func (v *_server) Stats() Stats {
+	return v.conf.counters.Stats()
+}
+
 func (v *_server) GetEventHandler(eventId event.Id) (h EventHandler, ok bool) {
 	return v.events.Get(eventId)
 }
@@ -100,53 +145,40 @@ func (v *_server) callGuards(clientId string, head http.Header) error {
 }
 
 func (v *_server) BroadcastEvent(eventId event.Id, message any) error {
-	ev := event.Pool.Get()
-	defer event.Pool.Put(ev)
-
-	ev.WithID(eventId)
-	if err := ev.Encode(message); err != nil {
+	if err := sendEvent(v.allConns(), eventEncoder(eventId, message)); err != nil {
 		logx.Error("WS Server", "do", "broadcast event", "err", err, "eventId", eventId)
-		return fmt.Errorf("encode message for id '%d': %w", eventId, err)
+		return err
 	}
 
-	b, err := json.Marshal(ev)
-	if err != nil {
+	if err := v.publish(BusBroadcast, "", nil, eventId, message); err != nil {
 		logx.Error("WS Server", "do", "broadcast event", "err", err, "eventId", eventId)
-		return fmt.Errorf("encode event for id '%d': %w", eventId, err)
+		return err
 	}
 
-	go func() {
-		for _, conn := range v.clients.Yield() {
-			conn.SendRawMessage(b)
-		}
-	}()
-
 	return nil
 }
 
 func (v *_server) SendEvent(eventId event.Id, message any, clientIDs ...string) error {
-	ev := event.Pool.Get()
-	defer event.Pool.Put(ev)
-
-	ev.WithID(eventId)
-	if err := ev.Encode(message); err != nil {
+	conns := v.localConns(clientIDs)
+	if err := sendEvent(conns, eventEncoder(eventId, message)); err != nil {
 		logx.Error("WS Server", "do", "send event", "err", err, "eventId", eventId)
-		return fmt.Errorf("encode message for id '%d': %w", eventId, err)
+		return err
 	}
 
-	b, err := json.Marshal(ev)
-	if err != nil {
-		logx.Error("WS Server", "do", "send event", "err", err, "eventId", eventId)
-		return fmt.Errorf("encode event for id '%d': %w", eventId, err)
+	if len(conns) == len(clientIDs) {
+		return nil
 	}
 
-	go func() {
-		for _, clientId := range clientIDs {
-			if conn, ok := v.clients.Get(clientId); ok {
-				conn.SendRawMessage(b)
-			}
+	remote := make([]string, 0, len(clientIDs)-len(conns))
+	for _, id := range clientIDs {
+		if !v.clients.Has(id) {
+			remote = append(remote, id)
 		}
-	}()
+	}
+	if err := v.publish(BusTarget, "", remote, eventId, message); err != nil {
+		logx.Error("WS Server", "do", "send event", "err", err, "eventId", eventId)
+		return err
+	}
 
 	return nil
 }
@@ -211,23 +243,36 @@ func (v *_server) HandlingHTTP(w http.ResponseWriter, r *http.Request) {
 			}
 		}()
 
+		principal, code, err := v.handshake(clientId, r)
+		if err != nil {
+			logx.Warn("WS Server", "do", "handling: guard", "err", err, "clientId", clientId)
+			http.Error(w, http.StatusText(code), code)
+			return
+		}
+
 		upgrade, err := v.upgrade.Upgrade(w, r, nil)
 		if err != nil {
 			logx.Error("WS Server", "do", "handling: upgrade new connect", "err", err, "clientId", clientId)
-			w.WriteHeader(http.StatusBadRequest)
 			return
 		}
 
-		if err = v.callGuards(clientId, r.Header); err != nil {
-			logx.Error("WS Server", "do", "handling: guard", "err", err, "clientId", clientId)
-			w.WriteHeader(http.StatusForbidden)
+		codec, err := resolveCodec(upgrade.Subprotocol(), v.codecs)
+		if err != nil {
+			logx.Error("WS Server", "do", "handling: resolve codec", "err", err, "clientId", clientId)
+			if err = upgrade.Close(); err != nil {
+				logx.Error("WS Server", "do", "handling: close connect", "err", err, "clientId", clientId)
+			}
 			return
 		}
 
 		ctx, cancel := xc.Join(ctx, r.Context())
 		defer cancel()
+		if !trace.SpanFromContext(ctx).Context().IsValid() {
+			ctx = trace.Extract(ctx, r.Header)
+		}
 
-		conn := newConnect(ctx, clientId, r.Header, v, upgrade)
+		conn := newConnect(ctx, clientId, r.Header, v, upgrade, codec, v.conf)
+		conn.principal = principal
 		conn.AddOnOpenFunc(func(string) { v.addConn(conn) })
 		conn.AddOnCloseFunc(func(cid string) { v.delConn(cid) })
 		conn.Run()
@@ -20,6 +20,7 @@ type (
 	Meta interface {
 		ConnectID() string
 		Head(key string) string
+		Principal() any
 		AddOnCloseFunc(cb func(cid string))
 		AddOnOpenFunc(cb func(cid string))
 		Context() context.Context
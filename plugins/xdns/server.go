@@ -23,6 +23,7 @@ type Server struct {
 	serv    []*dns.Server
 	handler HandlerDNS
 	qtypes  map[uint16]struct{}
+	observe ObserveFunc
 	wg      syncing.Group
 }
 
@@ -95,6 +96,12 @@ func (v *Server) HandleFunc(r HandlerDNS) {
 	v.handler = r
 }
 
+// Observe sets the func called for each question with the response code,
+// it must be set before the server is started
+func (v *Server) Observe(call ObserveFunc) {
+	v.observe = call
+}
+
 func (v *Server) dnsHandler(w dns.ResponseWriter, msg *dns.Msg) {
 	defer func() {
 		if err := recover(); err != nil {
@@ -145,4 +152,15 @@ func (v *Server) dnsHandler(w dns.ResponseWriter, msg *dns.Msg) {
 			"answer", response.String(),
 			"err", err)
 	}
+
+	if v.observe != nil {
+		rcode := dns.RcodeToString[response.Rcode]
+		for _, q := range msg.Question {
+			qtype := QTypeString(q.Qtype)
+			if len(qtype) == 0 {
+				qtype = "OTHER"
+			}
+			v.observe(qtype, rcode)
+		}
+	}
 }
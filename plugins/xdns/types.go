@@ -13,6 +13,9 @@ type HandlerDNS interface {
 	Exchange(q dns.Question) ([]dns.RR, error)
 }
 
+// ObserveFunc receives the question type and the response code of the handled question
+type ObserveFunc func(qtype, rcode string)
+
 type ZoneResolver interface {
 	Resolve(name string) []string
 }